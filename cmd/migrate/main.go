@@ -1,12 +1,14 @@
 package main
 
 import (
-	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"rpc/internal/config"
 )
 
@@ -22,44 +24,26 @@ func main() {
 		log.Fatalf("Failed to load config from %s: %v", configPath, err)
 	}
 
+	migrationsPath := os.Getenv("MIGRATIONS_PATH")
+	if migrationsPath == "" {
+		migrationsPath = "./migrations"
+	}
+
 	log.Printf("Connecting to database: %s@%s:%d/%s",
 		cfg.DbUser, cfg.DbHost, cfg.DbPort, cfg.DbName)
 
-	ctx := context.Background()
-
 	connStr := fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable",
 		cfg.DbUser, cfg.DbPass, cfg.DbHost, cfg.DbPort, cfg.DbName)
 
-	db, err := pgxpool.New(ctx, connStr)
-	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
-	}
-	defer db.Close()
-
-	// Проверим подключение
-	if err := db.Ping(ctx); err != nil {
-		log.Fatalf("Database ping failed: %v", err)
-	}
-
-	log.Println("Connected to PostgreSQL")
-
-	_, err = db.Exec(ctx, `
-		CREATE TABLE IF NOT EXISTS postgres (
-			id VARCHAR(36) PRIMARY KEY,
-			item VARCHAR(255) NOT NULL,
-			quantity INTEGER NOT NULL,
-			created_at TIMESTAMP DEFAULT NOW(),
-			updated_at TIMESTAMP DEFAULT NOW()
-		)
-	`)
+	m, err := migrate.New("file://"+migrationsPath, connStr)
 	if err != nil {
-		log.Fatalf("Failed to create table: %v", err)
+		log.Fatalf("Failed to init migrations: %v", err)
 	}
+	defer m.Close()
 
-	// Создаем индекс
-	_, err = db.Exec(ctx, `CREATE INDEX IF NOT EXISTS idx_orders_created_at ON orders(created_at)`)
-	if err != nil {
-		log.Fatalf("Failed to create index: %v", err)
+	// Накатываем все миграции из migrations/ по порядку
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		log.Fatalf("Failed to apply migrations: %v", err)
 	}
 
 	log.Println("Migrations completed successfully!")
@@ -7,6 +7,8 @@ import (
 	redislib "github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 	"log"
 	"net"
@@ -14,11 +16,15 @@ import (
 	"os/signal"
 	"rpc/internal/config"
 	"rpc/internal/gateway"
+	"rpc/internal/health"
+	"rpc/internal/idgen"
 	"rpc/internal/interceptor"
+	"rpc/internal/jobs"
 	"rpc/internal/repository/cached"
 	"rpc/internal/repository/postgres"
 	redisrepo "rpc/internal/repository/redis"
 	"rpc/internal/server"
+	"rpc/internal/watch"
 	"rpc/pkg/api/test"
 	"strconv"
 	"sync"
@@ -60,8 +66,8 @@ func main() {
 	}
 	defer db.Close()
 
-	ctxTimeout, cancel := context.WithTimeout(ctx, 5*time.Second)
-	defer cancel()
+	ctxTimeout, pingCancel := context.WithTimeout(ctx, 5*time.Second)
+	defer pingCancel()
 
 	if err := db.Ping(ctxTimeout); err != nil {
 		log.Fatalf("Database ping failed: %v", err)
@@ -84,10 +90,58 @@ func main() {
 	redisRepo := redisrepo.NewOrderRepository(redisClient)
 	cachedRepo := cached.NewCachedRepository(redisRepo, orderRepo)
 
-	grpcserver := grpc.NewServer(grpc.UnaryInterceptor(interceptor.ZapLog(logger)))
-	orderServer := server.NewServer(cachedRepo)
+	hub := watch.NewHub(postgres.NewChangeLog(db), logger)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		hub.Run(ctx)
+	}()
+
+	grpcserver := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(interceptor.ZapLog(logger), interceptor.Errors(), interceptor.Validate(), interceptor.Actor()),
+		grpc.ChainStreamInterceptor(interceptor.ZapLogStream(logger), interceptor.ErrorsStream(), interceptor.ValidateStream(), interceptor.ActorStream()),
+	)
+	ids, err := idgen.New(cfg.IDStrategy)
+	if err != nil {
+		log.Fatalf("Invalid ORDER_ID_STRATEGY: %v", err)
+	}
+	orderServer := server.NewServer(cachedRepo, hub, ids, cfg.PendingOrderTTL)
 	reflection.Register(grpcserver)
 	test.RegisterOrderServiceServer(grpcserver, orderServer)
+	test.RegisterInventoryServiceServer(grpcserver, server.NewInventoryServer(postgres.NewInventoryRepository(db)))
+
+	// статус сервисов в grpc.health.v1.Health следует за доступностью Postgres и Redis
+	healthServer := grpchealth.NewServer()
+	healthpb.RegisterHealthServer(grpcserver, healthServer)
+	checker := health.NewChecker(healthServer,
+		[]string{test.OrderService_ServiceDesc.ServiceName, test.InventoryService_ServiceDesc.ServiceName},
+		cfg.HealthInterval, logger,
+		health.Check{Name: "postgres", Ping: db.Ping},
+		health.Check{Name: "redis", Ping: func(ctx context.Context) error { return redisClient.Ping(ctx).Err() }},
+	)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		checker.Run(ctx)
+	}()
+
+	purger := jobs.NewPurger(cached.NewCachedPurger(redisRepo, postgres.NewOrderPurger(db)), cfg.DeletedRetention, cfg.ChangesRetention, cfg.PurgeInterval, logger)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		purger.Run(ctx)
+	}()
+
+	expirer := jobs.NewExpirer(cached.NewCachedExpirer(redisRepo, postgres.NewOrderExpirer(db)), cfg.ExpireInterval, logger)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		expirer.Run(ctx)
+	}()
 
 	logger.Info("Starting servers",
 		zap.String("grpc_port", strconv.Itoa(cfg.Port)),
@@ -112,7 +166,7 @@ func main() {
 		defer wg.Done()
 
 		logger.Info("HTTP gateway starting", zap.String("port", strconv.Itoa(cfg.GwPort)))
-		if err := gateway.StartGateway(ctx, "0.0.0.0:"+strconv.Itoa(cfg.Port), "0.0.0.0:"+strconv.Itoa(cfg.GwPort), logger); err != nil {
+		if err := gateway.StartGateway(ctx, "0.0.0.0:"+strconv.Itoa(cfg.Port), "0.0.0.0:"+strconv.Itoa(cfg.GwPort), cfg.ShutdownTimeout, logger); err != nil {
 			logger.Info("Failed to start gateway", zap.Error(err))
 		}
 	}()
@@ -125,6 +179,10 @@ func main() {
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
 	defer shutdownCancel()
 
+	// балансировщики и /readyz видят NOT_SERVING, пока идёт остановка
+	healthServer.Shutdown()
+
+	// останавливает фоновые задачи, потоки WatchOrders и приём запросов gateway
 	cancel()
 
 	grpcStopped := make(chan struct{})
@@ -141,6 +199,18 @@ func main() {
 		grpcserver.Stop()
 	}
 
-	wg.Wait()
-	logger.Info("grpcServer and GW stopped gracefully")
+	// пачка отмены по сроку или очистки откатывается при отмене ctx, так что ждать
+	// горутины дольше ShutdownTimeout незачем
+	workersStopped := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(workersStopped)
+	}()
+
+	select {
+	case <-workersStopped:
+		logger.Info("grpcServer and GW stopped gracefully")
+	case <-shutdownCtx.Done():
+		logger.Warn("Shutdown timeout exceeded, not all workers stopped")
+	}
 }
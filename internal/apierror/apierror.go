@@ -0,0 +1,241 @@
+// Package apierror приводит ошибки сервиса к статусам gRPC с машиночитаемыми
+// деталями (google.rpc.ErrorInfo, RetryInfo). Клиент видит только очищенное
+// сообщение, исходная ошибка остаётся в Error() для логов сервера.
+package apierror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"rpc/internal/repository"
+)
+
+// Domain - домен ошибок в google.rpc.ErrorInfo.
+const Domain = "orders.api"
+
+// Причины ошибок в google.rpc.ErrorInfo.
+const (
+	ReasonInvalidArgument        = "INVALID_ARGUMENT"
+	ReasonNotFound               = "NOT_FOUND"
+	ReasonOrderNotFound          = "ORDER_NOT_FOUND"
+	ReasonOrderAlreadyExists     = "ORDER_ALREADY_EXISTS"
+	ReasonStockNotFound          = "STOCK_NOT_FOUND"
+	ReasonConcurrentModification = "CONCURRENT_MODIFICATION"
+	ReasonFailedPrecondition     = "FAILED_PRECONDITION"
+	ReasonInvalidTransition      = "INVALID_TRANSITION"
+	ReasonResumeTokenExpired     = "RESUME_TOKEN_EXPIRED"
+	ReasonWatchLagging           = "WATCH_LAGGING"
+	ReasonResourceExhausted      = "RESOURCE_EXHAUSTED"
+	ReasonOutOfRange             = "OUT_OF_RANGE"
+	ReasonUnimplemented          = "UNIMPLEMENTED"
+	ReasonUnavailable            = "UNAVAILABLE"
+	ReasonDeadlineExceeded       = "DEADLINE_EXCEEDED"
+	ReasonCanceled               = "CANCELED"
+	ReasonInternal               = "INTERNAL"
+)
+
+// RetryDelay - через сколько советуем клиенту повторить запрос после временной ошибки.
+const RetryDelay = time.Second
+
+// resourceReasons - причины NotFound по типу ресурса из google.rpc.ResourceInfo.
+var resourceReasons = map[string]string{
+	repository.OrderResource: ReasonOrderNotFound,
+	repository.StockResource: ReasonStockNotFound,
+}
+
+var codeReasons = map[codes.Code]string{
+	codes.InvalidArgument:    ReasonInvalidArgument,
+	codes.NotFound:           ReasonNotFound,
+	codes.AlreadyExists:      ReasonOrderAlreadyExists,
+	codes.Aborted:            ReasonConcurrentModification,
+	codes.FailedPrecondition: ReasonFailedPrecondition,
+	codes.ResourceExhausted:  ReasonResourceExhausted,
+	codes.OutOfRange:         ReasonOutOfRange,
+	codes.Unimplemented:      ReasonUnimplemented,
+	codes.Unavailable:        ReasonUnavailable,
+	codes.DeadlineExceeded:   ReasonDeadlineExceeded,
+	codes.Canceled:           ReasonCanceled,
+	codes.Internal:           ReasonInternal,
+}
+
+// Error - статус для клиента вместе с исходной ошибкой.
+type Error struct {
+	st    *status.Status
+	cause error
+}
+
+func (e *Error) Error() string {
+	if e.cause == nil {
+		return e.st.Message()
+	}
+	return fmt.Sprintf("%s: %v", e.st.Message(), e.cause)
+}
+
+// GRPCStatus отдаёт grpc статус без исходной ошибки.
+func (e *Error) GRPCStatus() *status.Status { return e.st }
+
+func (e *Error) Unwrap() error { return e.cause }
+
+// New возвращает ошибку с заданной причиной; metadata - пары ключ, значение.
+func New(code codes.Code, reason, msg string, metadata ...string) error {
+	return &Error{st: withInfo(status.New(code, msg), reason, metadata, false)}
+}
+
+// Newf как New, но без metadata и с форматированием сообщения.
+func Newf(code codes.Code, reason, format string, args ...any) error {
+	return New(code, reason, fmt.Sprintf(format, args...))
+}
+
+// From приводит ошибку репозитория или обработчика к ошибке API.
+// action описывает неудавшееся действие ("get order") и попадает в
+// сообщение для внутренних и временных ошибок; metadata - пары ключ, значение.
+// Статусы с уже заданным ErrorInfo возвращаются как есть.
+func From(err error, action string, metadata ...string) error {
+	if err == nil {
+		return nil
+	}
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return err
+	}
+
+	if st, ok := status.FromError(err); ok {
+		if hasInfo(st) {
+			return err
+		}
+		code := st.Code()
+		reason, known := codeReasons[code]
+		if !known || code == codes.Internal || code == codes.Unknown {
+			return internal(err, action, metadata)
+		}
+		if code == codes.NotFound {
+			reason = notFoundReason(st)
+		}
+		return &Error{st: withInfo(st, reason, metadata, retryable(code))}
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return &Error{st: withInfo(status.New(codes.Canceled, "request canceled"), ReasonCanceled, metadata, false), cause: err}
+	case errors.Is(err, context.DeadlineExceeded):
+		return &Error{st: withInfo(status.New(codes.DeadlineExceeded, failed(action, "deadline exceeded")), ReasonDeadlineExceeded, metadata, true), cause: err}
+	}
+	if code, ok := transient(err); ok {
+		msg := "service temporarily unavailable, retry later"
+		if code == codes.Aborted {
+			msg = "conflicting concurrent transaction, retry later"
+		}
+		return &Error{st: withInfo(status.New(code, failed(action, msg)), codeReasons[code], metadata, true), cause: err}
+	}
+	return internal(err, action, metadata)
+}
+
+// Prefix дописывает prefix в начало сообщения ошибки API; код, детали и исходная
+// ошибка сохраняются.
+func Prefix(err error, prefix string) error {
+	if err == nil {
+		return nil
+	}
+	st := status.Convert(err)
+	var cause error
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		st, cause = apiErr.st, apiErr.cause
+	}
+	p := st.Proto()
+	p.Message = prefix + ": " + p.Message
+	return &Error{st: status.FromProto(p), cause: cause}
+}
+
+func internal(err error, action string, metadata []string) error {
+	return &Error{st: withInfo(status.New(codes.Internal, failed(action, "internal error")), ReasonInternal, metadata, false), cause: err}
+}
+
+func failed(action, msg string) string {
+	if action == "" {
+		return msg
+	}
+	return fmt.Sprintf("failed to %s: %s", action, msg)
+}
+
+func retryable(code codes.Code) bool {
+	return code == codes.Unavailable
+}
+
+// transient распознаёт временные ошибки базы, после которых запрос можно повторить.
+func transient(err error) (codes.Code, bool) {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch {
+		case pgErr.Code == "40001", pgErr.Code == "40P01":
+			// serialization_failure, deadlock_detected
+			return codes.Aborted, true
+		case strings.HasPrefix(pgErr.Code, "08"), strings.HasPrefix(pgErr.Code, "53"),
+			pgErr.Code == "57P01", pgErr.Code == "57P02", pgErr.Code == "57P03":
+			// ошибки соединения, нехватка ресурсов, остановка сервера
+			return codes.Unavailable, true
+		}
+		return codes.OK, false
+	}
+
+	var connErr *pgconn.ConnectError
+	var netErr net.Error
+	switch {
+	case errors.As(err, &connErr), pgconn.Timeout(err), errors.As(err, &netErr):
+		return codes.Unavailable, true
+	}
+	return codes.OK, false
+}
+
+// notFoundReason выбирает причину NotFound по ресурсу, которого не нашлось.
+func notFoundReason(st *status.Status) string {
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ResourceInfo); ok {
+			if reason, ok := resourceReasons[info.ResourceType]; ok {
+				return reason
+			}
+		}
+	}
+	return ReasonNotFound
+}
+
+func hasInfo(st *status.Status) bool {
+	for _, d := range st.Details() {
+		if _, ok := d.(*errdetails.ErrorInfo); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// withInfo добавляет к статусу ErrorInfo и, для временных ошибок, RetryInfo,
+// сохраняя уже приложенные детали (например, BadRequest).
+func withInfo(st *status.Status, reason string, metadata []string, retry bool) *status.Status {
+	info := &errdetails.ErrorInfo{Reason: reason, Domain: Domain}
+	if len(metadata) > 1 {
+		info.Metadata = make(map[string]string, len(metadata)/2)
+		for i := 0; i+1 < len(metadata); i += 2 {
+			info.Metadata[metadata[i]] = metadata[i+1]
+		}
+	}
+
+	out, err := st.WithDetails(info)
+	if err != nil {
+		return st
+	}
+	if retry {
+		if with, err := out.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(RetryDelay)}); err == nil {
+			out = with
+		}
+	}
+	return out
+}
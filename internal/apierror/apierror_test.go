@@ -0,0 +1,234 @@
+package apierror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"rpc/internal/repository"
+)
+
+func TestFrom(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantCode  codes.Code
+		wantMsg   string
+		wantInfo  string
+		wantRetry bool
+	}{
+		{
+			name:     "order not found",
+			err:      repository.OrderNotFound("order-1"),
+			wantCode: codes.NotFound,
+			wantMsg:  "order with id order-1 not found",
+			wantInfo: ReasonOrderNotFound,
+		},
+		{
+			name:     "orders not found",
+			err:      repository.OrdersNotFound([]string{"order-1", "order-2"}),
+			wantCode: codes.NotFound,
+			wantMsg:  "orders not found: order-1, order-2",
+			wantInfo: ReasonOrderNotFound,
+		},
+		{
+			name:     "stock not found",
+			err:      repository.StockNotFound("Laptop"),
+			wantCode: codes.NotFound,
+			wantMsg:  `stock of item "Laptop" not found`,
+			wantInfo: ReasonStockNotFound,
+		},
+		{
+			name:     "not found without resource",
+			err:      status.Error(codes.NotFound, "idempotency key not found"),
+			wantCode: codes.NotFound,
+			wantMsg:  "idempotency key not found",
+			wantInfo: ReasonNotFound,
+		},
+		{
+			name:     "invalid argument",
+			err:      status.Error(codes.InvalidArgument, "invalid page token"),
+			wantCode: codes.InvalidArgument,
+			wantMsg:  "invalid page token",
+			wantInfo: ReasonInvalidArgument,
+		},
+		{
+			name:      "unavailable status",
+			err:       status.Error(codes.Unavailable, "cache is down"),
+			wantCode:  codes.Unavailable,
+			wantMsg:   "cache is down",
+			wantInfo:  ReasonUnavailable,
+			wantRetry: true,
+		},
+		{
+			name:     "internal status",
+			err:      status.Error(codes.Internal, "secret detail"),
+			wantCode: codes.Internal,
+			wantMsg:  "failed to get order: internal error",
+			wantInfo: ReasonInternal,
+		},
+		{
+			name:     "unknown error",
+			err:      errors.New("secret detail"),
+			wantCode: codes.Internal,
+			wantMsg:  "failed to get order: internal error",
+			wantInfo: ReasonInternal,
+		},
+		{
+			name:     "canceled",
+			err:      fmt.Errorf("query: %w", context.Canceled),
+			wantCode: codes.Canceled,
+			wantMsg:  "request canceled",
+			wantInfo: ReasonCanceled,
+		},
+		{
+			name:      "deadline",
+			err:       fmt.Errorf("query: %w", context.DeadlineExceeded),
+			wantCode:  codes.DeadlineExceeded,
+			wantMsg:   "failed to get order: deadline exceeded",
+			wantInfo:  ReasonDeadlineExceeded,
+			wantRetry: true,
+		},
+		{
+			name:      "serialization failure",
+			err:       &pgconn.PgError{Code: "40001"},
+			wantCode:  codes.Aborted,
+			wantMsg:   "failed to get order: conflicting concurrent transaction, retry later",
+			wantInfo:  ReasonConcurrentModification,
+			wantRetry: true,
+		},
+		{
+			name:      "deadlock",
+			err:       fmt.Errorf("release stock: %w", &pgconn.PgError{Code: "40P01"}),
+			wantCode:  codes.Aborted,
+			wantMsg:   "failed to get order: conflicting concurrent transaction, retry later",
+			wantInfo:  ReasonConcurrentModification,
+			wantRetry: true,
+		},
+		{
+			name:      "connection failure",
+			err:       &pgconn.PgError{Code: "08006"},
+			wantCode:  codes.Unavailable,
+			wantMsg:   "failed to get order: service temporarily unavailable, retry later",
+			wantInfo:  ReasonUnavailable,
+			wantRetry: true,
+		},
+		{
+			name:      "too many connections",
+			err:       &pgconn.PgError{Code: "53300"},
+			wantCode:  codes.Unavailable,
+			wantMsg:   "failed to get order: service temporarily unavailable, retry later",
+			wantInfo:  ReasonUnavailable,
+			wantRetry: true,
+		},
+		{
+			name:      "admin shutdown",
+			err:       &pgconn.PgError{Code: "57P01"},
+			wantCode:  codes.Unavailable,
+			wantMsg:   "failed to get order: service temporarily unavailable, retry later",
+			wantInfo:  ReasonUnavailable,
+			wantRetry: true,
+		},
+		{
+			name:     "constraint violation",
+			err:      &pgconn.PgError{Code: "23505"},
+			wantCode: codes.Internal,
+			wantMsg:  "failed to get order: internal error",
+			wantInfo: ReasonInternal,
+		},
+		{
+			name:      "network error",
+			err:       &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")},
+			wantCode:  codes.Unavailable,
+			wantMsg:   "failed to get order: service temporarily unavailable, retry later",
+			wantInfo:  ReasonUnavailable,
+			wantRetry: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := From(tt.err, "get order", "order_id", "order-1")
+			st := status.Convert(err)
+
+			if st.Code() != tt.wantCode {
+				t.Errorf("code = %s, want %s", st.Code(), tt.wantCode)
+			}
+			if st.Message() != tt.wantMsg {
+				t.Errorf("message = %q, want %q", st.Message(), tt.wantMsg)
+			}
+
+			var info *errdetails.ErrorInfo
+			var retry *errdetails.RetryInfo
+			for _, d := range st.Details() {
+				switch d := d.(type) {
+				case *errdetails.ErrorInfo:
+					info = d
+				case *errdetails.RetryInfo:
+					retry = d
+				}
+			}
+			if info == nil {
+				t.Fatalf("details = %v, want ErrorInfo", st.Details())
+			}
+			if info.Reason != tt.wantInfo || info.Domain != Domain {
+				t.Errorf("ErrorInfo = %s/%s, want %s/%s", info.Domain, info.Reason, Domain, tt.wantInfo)
+			}
+			if want := map[string]string{"order_id": "order-1"}; !reflect.DeepEqual(info.Metadata, want) {
+				t.Errorf("metadata = %v, want %v", info.Metadata, want)
+			}
+			if (retry != nil) != tt.wantRetry {
+				t.Errorf("RetryInfo = %v, want retry %v", retry, tt.wantRetry)
+			}
+			if retry != nil && retry.RetryDelay.AsDuration() != RetryDelay {
+				t.Errorf("retry delay = %s, want %s", retry.RetryDelay.AsDuration(), RetryDelay)
+			}
+		})
+	}
+}
+
+func TestFromKeepsCause(t *testing.T) {
+	cause := errors.New("secret detail")
+	err := From(cause, "get order")
+
+	if !errors.Is(err, cause) {
+		t.Errorf("errors.Is(%v, cause) = false", err)
+	}
+	if want := "failed to get order: internal error: secret detail"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestFromKeepsErrorInfo(t *testing.T) {
+	err := New(codes.FailedPrecondition, ReasonInvalidTransition, "order is shipped")
+	if got := From(err, "update order"); got != err {
+		t.Errorf("From() = %v, want the error unchanged", got)
+	}
+
+	if got := From(nil, "get order"); got != nil {
+		t.Errorf("From(nil) = %v, want nil", got)
+	}
+}
+
+func TestPrefix(t *testing.T) {
+	cause := errors.New("secret detail")
+	err := Prefix(From(cause, "import orders", "imported", "10"), "10 orders imported before the error")
+
+	st := status.Convert(err)
+	if want := "10 orders imported before the error: failed to import orders: internal error"; st.Message() != want {
+		t.Errorf("message = %q, want %q", st.Message(), want)
+	}
+	if st.Code() != codes.Internal || len(st.Details()) != 1 {
+		t.Errorf("status = %s with %v, want Internal with ErrorInfo", st.Code(), st.Details())
+	}
+	if !errors.Is(err, cause) {
+		t.Errorf("errors.Is(%v, cause) = false", err)
+	}
+}
@@ -7,20 +7,27 @@ import (
 )
 
 type Config struct {
-	Port            int           `env:"GRPC_PORT" env-default:"50051"`
-	LogLevel        string        `env:"LOG_LEVEL" env-default:"info"`
-	Timeout         time.Duration `env:"HTTP_TIMEOUT" env-default:"30s"`
-	GwPort          int           `env:"GRPC_GATEWAY_PORT" env-default:"8080"`
-	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT" env-default:"30s"`
-	RedisHost       string        `env:"REDIS_HOST" env-default:"localhost"`
-	RedisPort       string        `env:"REDIS_PORT" env-default:"6379"`
-	RedisPassword   string        `env:"REDIS_PASSWORD" env-default:""`
-	DbName          string        `env:"POSTGRES_DB" env-default:"postgres"`
-	DbUser          string        `env:"POSTGRES_USER" env-default:"postgres"`
-	DbPass          string        `env:"POSTGRES_PASSWORD" env-default:"postgres"`
-	DbHost          string        `env:"POSTGRES_HOST" env-default:"db"`
-	DbPort          int           `env:"POSTGRES_PORT" env-default:"5432"`
-	PostgresVersion string        `env:"POSTGRES_VERSION" env-default:"15"`
+	Port             int           `env:"GRPC_PORT" env-default:"50051"`
+	LogLevel         string        `env:"LOG_LEVEL" env-default:"info"`
+	Timeout          time.Duration `env:"HTTP_TIMEOUT" env-default:"30s"`
+	GwPort           int           `env:"GRPC_GATEWAY_PORT" env-default:"8080"`
+	ShutdownTimeout  time.Duration `env:"SHUTDOWN_TIMEOUT" env-default:"30s"`
+	RedisHost        string        `env:"REDIS_HOST" env-default:"localhost"`
+	RedisPort        string        `env:"REDIS_PORT" env-default:"6379"`
+	RedisPassword    string        `env:"REDIS_PASSWORD" env-default:""`
+	DbName           string        `env:"POSTGRES_DB" env-default:"postgres"`
+	DbUser           string        `env:"POSTGRES_USER" env-default:"postgres"`
+	DbPass           string        `env:"POSTGRES_PASSWORD" env-default:"postgres"`
+	DbHost           string        `env:"POSTGRES_HOST" env-default:"db"`
+	DbPort           int           `env:"POSTGRES_PORT" env-default:"5432"`
+	PostgresVersion  string        `env:"POSTGRES_VERSION" env-default:"15"`
+	DeletedRetention time.Duration `env:"DELETED_RETENTION" env-default:"720h"`
+	PurgeInterval    time.Duration `env:"PURGE_INTERVAL" env-default:"1h"`
+	ChangesRetention time.Duration `env:"CHANGES_RETENTION" env-default:"168h"`
+	IDStrategy       string        `env:"ORDER_ID_STRATEGY" env-default:"uuidv7"`
+	PendingOrderTTL  time.Duration `env:"PENDING_ORDER_TTL" env-default:"24h"`
+	ExpireInterval   time.Duration `env:"EXPIRE_INTERVAL" env-default:"1m"`
+	HealthInterval   time.Duration `env:"HEALTH_CHECK_INTERVAL" env-default:"5s"`
 }
 
 func ParseConfig(path string) (*Config, error) {
@@ -28,5 +35,26 @@ func ParseConfig(path string) (*Config, error) {
 	if err := cleanenv.ReadConfig(path, cfg); err != nil {
 		return nil, fmt.Errorf("failed to parse config: %w", err)
 	}
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
 	return cfg, nil
 }
+
+// validate отсекает интервалы фоновых задач, на которых time.NewTicker паникует.
+func (c *Config) validate() error {
+	intervals := []struct {
+		env   string
+		value time.Duration
+	}{
+		{"PURGE_INTERVAL", c.PurgeInterval},
+		{"EXPIRE_INTERVAL", c.ExpireInterval},
+		{"HEALTH_CHECK_INTERVAL", c.HealthInterval},
+	}
+	for _, interval := range intervals {
+		if interval.value <= 0 {
+			return fmt.Errorf("%s must be positive, got %s", interval.env, interval.value)
+		}
+	}
+	return nil
+}
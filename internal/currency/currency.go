@@ -0,0 +1,91 @@
+// Package currency переводит google.type.Money в целые минорные единицы валюты
+// (центы, копейки) и обратно. Деньги в базе хранятся только в минорных единицах.
+package currency
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/type/money"
+)
+
+const nanosPerUnit = 1_000_000_000
+
+// exponents - число знаков после запятой у валют ISO 4217, отличное от двух.
+var exponents = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+// codes - действующие коды ISO 4217 с двумя знаками после запятой.
+var codes = strings.Fields(`
+	AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BMD BND BOB BOV BRL BSD
+	BTN BWP BYN BZD CAD CDF CHE CHF CHW CNY COP COU CRC CUP CVE CZK DKK DOP DZD EGP
+	ERN ETB EUR FJD FKP GBP GEL GHS GIP GMD GTQ GYD HKD HNL HTG HUF IDR ILS INR IRR
+	JMD KES KGS KHR KPW KYD KZT LAK LBP LKR LRD LSL MAD MDL MGA MKD MMK MNT MOP MRU
+	MUR MVR MWK MXN MXV MYR MZN NAD NGN NIO NOK NPR NZD PAB PEN PGK PHP PKR PLN QAR
+	RON RSD RUB SAR SBD SCR SDG SEK SGD SHP SLE SOS SRD SSP STN SVC SYP SZL THB TJS
+	TMT TOP TRY TTD TWD TZS UAH USD USN UYU UZS VED VES WST XCD YER ZAR ZMW ZWG
+`)
+
+func init() {
+	for _, code := range codes {
+		exponents[code] = 2
+	}
+}
+
+// Exponent возвращает число знаков после запятой у валюты.
+func Exponent(code string) (int, error) {
+	exp, ok := exponents[code]
+	if !ok {
+		return 0, fmt.Errorf("unknown currency code %q", code)
+	}
+	return exp, nil
+}
+
+// ToMinor переводит сумму в минорные единицы её валюты. Сумма с точностью выше,
+// чем у валюты (например, доли цента), отклоняется.
+func ToMinor(m *money.Money) (int64, error) {
+	exp, err := Exponent(m.GetCurrencyCode())
+	if err != nil {
+		return 0, err
+	}
+
+	units, nanos := m.GetUnits(), int64(m.GetNanos())
+	if nanos <= -nanosPerUnit || nanos >= nanosPerUnit {
+		return 0, fmt.Errorf("nanos must be between -999999999 and 999999999")
+	}
+	if (units > 0 && nanos < 0) || (units < 0 && nanos > 0) {
+		return 0, fmt.Errorf("units and nanos must have the same sign")
+	}
+
+	scale := int64(math.Pow10(exp))
+	step := int64(nanosPerUnit) / scale
+	if nanos%step != 0 {
+		return 0, fmt.Errorf("%s amounts have at most %d decimal places", m.GetCurrencyCode(), exp)
+	}
+
+	if units > math.MaxInt64/scale || units < math.MinInt64/scale {
+		return 0, fmt.Errorf("amount is too large")
+	}
+	// units и nanos одного знака, так что переполниться может только в их сторону
+	whole, frac := units*scale, nanos/step
+	if (frac > 0 && whole > math.MaxInt64-frac) || (frac < 0 && whole < math.MinInt64-frac) {
+		return 0, fmt.Errorf("amount is too large")
+	}
+	return whole + frac, nil
+}
+
+// FromMinor собирает Money из минорных единиц валюты.
+func FromMinor(code string, minor int64) *money.Money {
+	exp, _ := Exponent(code)
+	scale := int64(math.Pow10(exp))
+	return &money.Money{
+		CurrencyCode: code,
+		Units:        minor / scale,
+		Nanos:        int32(minor % scale * (nanosPerUnit / scale)),
+	}
+}
@@ -0,0 +1,106 @@
+package currency
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/type/money"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestExponent(t *testing.T) {
+	tests := []struct {
+		code    string
+		want    int
+		wantErr bool
+	}{
+		{code: "USD", want: 2},
+		{code: "JPY", want: 0},
+		{code: "KWD", want: 3},
+		{code: "CLF", want: 4},
+		{code: "usd", wantErr: true},
+		{code: "XXX", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			got, err := Exponent(tt.code)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Exponent(%q) error = %v, wantErr %v", tt.code, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Exponent(%q) = %d, want %d", tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToMinor(t *testing.T) {
+	tests := []struct {
+		name    string
+		money   *money.Money
+		want    int64
+		wantErr string
+	}{
+		{name: "dollars and cents", money: &money.Money{CurrencyCode: "USD", Units: 12, Nanos: 340_000_000}, want: 1234},
+		{name: "negative", money: &money.Money{CurrencyCode: "USD", Units: -1, Nanos: -500_000_000}, want: -150},
+		{name: "only nanos", money: &money.Money{CurrencyCode: "USD", Nanos: 10_000_000}, want: 1},
+		{name: "zero exponent", money: &money.Money{CurrencyCode: "JPY", Units: 500}, want: 500},
+		{name: "three decimals", money: &money.Money{CurrencyCode: "KWD", Units: 1, Nanos: 1_000_000}, want: 1001},
+		{name: "fraction of a cent", money: &money.Money{CurrencyCode: "USD", Nanos: 5_000_000}, wantErr: "at most 2 decimal places"},
+		{name: "fraction of a yen", money: &money.Money{CurrencyCode: "JPY", Units: 1, Nanos: 500_000_000}, wantErr: "at most 0 decimal places"},
+		{name: "mixed signs", money: &money.Money{CurrencyCode: "USD", Units: 1, Nanos: -10_000_000}, wantErr: "same sign"},
+		{name: "nanos out of range", money: &money.Money{CurrencyCode: "USD", Nanos: 1_000_000_000}, wantErr: "nanos must be between"},
+		{name: "unknown currency", money: &money.Money{CurrencyCode: "ABC", Units: 1}, wantErr: "unknown currency code"},
+		{name: "units overflow", money: &money.Money{CurrencyCode: "USD", Units: math.MaxInt64 / 10}, wantErr: "too large"},
+		{name: "largest", money: &money.Money{CurrencyCode: "USD", Units: math.MaxInt64 / 100, Nanos: 70_000_000}, want: math.MaxInt64},
+		{name: "nanos overflow", money: &money.Money{CurrencyCode: "USD", Units: math.MaxInt64 / 100, Nanos: 80_000_000}, wantErr: "too large"},
+		{name: "smallest", money: &money.Money{CurrencyCode: "USD", Units: math.MinInt64 / 100, Nanos: -80_000_000}, want: math.MinInt64},
+		{name: "negative nanos overflow", money: &money.Money{CurrencyCode: "USD", Units: math.MinInt64 / 100, Nanos: -90_000_000}, wantErr: "too large"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ToMinor(tt.money)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ToMinor(%v) = %d, %v, want error containing %q", tt.money, got, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ToMinor(%v) error: %v", tt.money, err)
+			}
+			if got != tt.want {
+				t.Errorf("ToMinor(%v) = %d, want %d", tt.money, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromMinor(t *testing.T) {
+	tests := []struct {
+		code  string
+		minor int64
+		want  *money.Money
+	}{
+		{code: "USD", minor: 1234, want: &money.Money{CurrencyCode: "USD", Units: 12, Nanos: 340_000_000}},
+		{code: "USD", minor: -150, want: &money.Money{CurrencyCode: "USD", Units: -1, Nanos: -500_000_000}},
+		{code: "JPY", minor: 500, want: &money.Money{CurrencyCode: "JPY", Units: 500}},
+		{code: "KWD", minor: 1001, want: &money.Money{CurrencyCode: "KWD", Units: 1, Nanos: 1_000_000}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			got := FromMinor(tt.code, tt.minor)
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("FromMinor(%q, %d) = %v, want %v", tt.code, tt.minor, got, tt.want)
+			}
+			back, err := ToMinor(got)
+			if err != nil || back != tt.minor {
+				t.Errorf("ToMinor(FromMinor(%q, %d)) = %d, %v", tt.code, tt.minor, back, err)
+			}
+		})
+	}
+}
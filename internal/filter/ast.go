@@ -0,0 +1,50 @@
+// Package filter разбирает выражения фильтрации и сортировки в стиле AIP-160/AIP-132
+// (https://google.aip.dev/160, https://google.aip.dev/132) в дерево, которое затем
+// транслируется в SQL репозиторием. Пакет ничего не знает о конкретных полях:
+// проверка имён и типов значений - забота того, кто обходит дерево.
+package filter
+
+// Operator - оператор сравнения в ограничении.
+type Operator string
+
+const (
+	OpEq  Operator = "="
+	OpNe  Operator = "!="
+	OpLt  Operator = "<"
+	OpLe  Operator = "<="
+	OpGt  Operator = ">"
+	OpGe  Operator = ">="
+	OpHas Operator = ":"
+)
+
+// Expr - узел дерева фильтра: And, Or, Not или Restriction.
+type Expr interface {
+	isExpr()
+}
+
+// And истинно, когда истинны все подвыражения.
+type And struct {
+	Exprs []Expr
+}
+
+// Or истинно, когда истинно хотя бы одно подвыражение.
+type Or struct {
+	Exprs []Expr
+}
+
+// Not отрицает подвыражение.
+type Not struct {
+	Expr Expr
+}
+
+// Restriction - сравнение поля со значением, например quantity >= 5.
+type Restriction struct {
+	Field string
+	Op    Operator
+	Value string
+}
+
+func (And) isExpr()         {}
+func (Or) isExpr()          {}
+func (Not) isExpr()         {}
+func (Restriction) isExpr() {}
@@ -0,0 +1,108 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokText
+	tokString
+	tokOp
+	tokLParen
+	tokRParen
+	tokMinus
+	tokAnd
+	tokOr
+	tokNot
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func isOpChar(r rune) bool {
+	return r == '=' || r == '!' || r == '<' || r == '>' || r == ':'
+}
+
+func isTextChar(r rune) bool {
+	return !unicode.IsSpace(r) && !isOpChar(r) && r != '(' && r != ')' && r != '"' && r != '\''
+}
+
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	runes := []rune(input)
+
+	prevIsOp := func() bool {
+		return len(tokens) > 0 && tokens[len(tokens)-1].kind == tokOp
+	}
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokLParen, text: "(", pos: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokRParen, text: ")", pos: i})
+			i++
+		case r == '"' || r == '\'':
+			start := i
+			var sb strings.Builder
+			i++
+			for ; i < len(runes) && runes[i] != r; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				sb.WriteRune(runes[i])
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated string at position %d", start)
+			}
+			i++
+			tokens = append(tokens, token{kind: tokString, text: sb.String(), pos: start})
+		case isOpChar(r):
+			start := i
+			op := string(r)
+			if i+1 < len(runes) && runes[i+1] == '=' && r != '=' && r != ':' {
+				op += "="
+			}
+			switch Operator(op) {
+			case OpEq, OpNe, OpLt, OpLe, OpGt, OpGe, OpHas:
+			default:
+				return nil, fmt.Errorf("unknown operator %q at position %d", op, start)
+			}
+			i += len(op)
+			tokens = append(tokens, token{kind: tokOp, text: op, pos: start})
+		case r == '-' && !prevIsOp() && i+1 < len(runes) && (unicode.IsLetter(runes[i+1]) || runes[i+1] == '('):
+			tokens = append(tokens, token{kind: tokMinus, text: "-", pos: i})
+			i++
+		default:
+			start := i
+			for i < len(runes) && isTextChar(runes[i]) {
+				i++
+			}
+			text := string(runes[start:i])
+			kind := tokText
+			switch text {
+			case "AND":
+				kind = tokAnd
+			case "OR":
+				kind = tokOr
+			case "NOT":
+				kind = tokNot
+			}
+			tokens = append(tokens, token{kind: kind, text: text, pos: start})
+		}
+	}
+
+	return append(tokens, token{kind: tokEOF, pos: len(runes)}), nil
+}
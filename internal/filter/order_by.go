@@ -0,0 +1,48 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OrderField - одно поле сортировки из order_by.
+type OrderField struct {
+	Field string
+	Desc  bool
+}
+
+// ParseOrderBy разбирает строку вида "quantity desc, item" (AIP-132).
+// Пустая строка даёт nil без ошибки.
+func ParseOrderBy(input string) ([]OrderField, error) {
+	if strings.TrimSpace(input) == "" {
+		return nil, nil
+	}
+
+	var fields []OrderField
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(input, ",") {
+		words := strings.Fields(part)
+		if len(words) == 0 || len(words) > 2 {
+			return nil, fmt.Errorf("malformed order_by clause %q", strings.TrimSpace(part))
+		}
+
+		field := OrderField{Field: words[0]}
+		if len(words) == 2 {
+			switch strings.ToLower(words[1]) {
+			case "asc":
+			case "desc":
+				field.Desc = true
+			default:
+				return nil, fmt.Errorf("unknown sort direction %q", words[1])
+			}
+		}
+
+		if seen[field.Field] {
+			return nil, fmt.Errorf("field %q is listed in order_by more than once", field.Field)
+		}
+		seen[field.Field] = true
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
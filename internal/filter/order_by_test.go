@@ -0,0 +1,46 @@
+package filter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseOrderBy(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []OrderField
+		wantErr string
+	}{
+		{name: "empty", input: " ", want: nil},
+		{name: "single", input: "quantity", want: []OrderField{{Field: "quantity"}}},
+		{
+			name:  "directions",
+			input: "quantity desc, item ASC,create_time",
+			want:  []OrderField{{Field: "quantity", Desc: true}, {Field: "item"}, {Field: "create_time"}},
+		},
+		{name: "unknown direction", input: "quantity down", wantErr: `unknown sort direction "down"`},
+		{name: "too many words", input: "quantity desc item", wantErr: "malformed order_by clause"},
+		{name: "empty clause", input: "quantity,,item", wantErr: "malformed order_by clause"},
+		{name: "duplicate", input: "item, item desc", wantErr: "more than once"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseOrderBy(tt.input)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseOrderBy(%q) error = %v, want containing %q", tt.input, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseOrderBy(%q) error: %v", tt.input, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseOrderBy(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
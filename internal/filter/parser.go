@@ -0,0 +1,196 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	maxFilterLength = 2048
+	maxDepth        = 32
+)
+
+// Parse разбирает выражение фильтра. Пустая строка даёт nil без ошибки.
+//
+// Поддерживается подмножество грамматики AIP-160:
+//
+//	expression  = sequence {"AND" sequence}
+//	sequence    = factor {factor}
+//	factor      = term {"OR" term}
+//	term        = ["NOT" | "-"] simple
+//	simple      = restriction | "(" expression ")"
+//	restriction = field operator value
+//
+// Как и в AIP-160, OR связывает сильнее AND.
+func Parse(input string) (Expr, error) {
+	if strings.TrimSpace(input) == "" {
+		return nil, nil
+	}
+	if len(input) > maxFilterLength {
+		return nil, fmt.Errorf("filter is longer than %d bytes", maxFilterLength)
+	}
+
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	expr, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos)
+	}
+
+	return expr, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	depth  int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) expression() (Expr, error) {
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > maxDepth {
+		return nil, fmt.Errorf("filter is nested deeper than %d levels", maxDepth)
+	}
+
+	first, err := p.sequence()
+	if err != nil {
+		return nil, err
+	}
+
+	exprs := []Expr{first}
+	for p.peek().kind == tokAnd {
+		p.next()
+		e, err := p.sequence()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, e)
+	}
+
+	if len(exprs) == 1 {
+		return first, nil
+	}
+	return And{Exprs: exprs}, nil
+}
+
+func (p *parser) sequence() (Expr, error) {
+	first, err := p.factor()
+	if err != nil {
+		return nil, err
+	}
+
+	exprs := []Expr{first}
+	for startsTerm(p.peek()) {
+		e, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, e)
+	}
+
+	if len(exprs) == 1 {
+		return first, nil
+	}
+	return And{Exprs: exprs}, nil
+}
+
+func (p *parser) factor() (Expr, error) {
+	first, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+
+	exprs := []Expr{first}
+	for p.peek().kind == tokOr {
+		p.next()
+		e, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, e)
+	}
+
+	if len(exprs) == 1 {
+		return first, nil
+	}
+	return Or{Exprs: exprs}, nil
+}
+
+func (p *parser) term() (Expr, error) {
+	if kind := p.peek().kind; kind == tokNot || kind == tokMinus {
+		p.next()
+		e, err := p.simple()
+		if err != nil {
+			return nil, err
+		}
+		return Not{Expr: e}, nil
+	}
+	return p.simple()
+}
+
+func (p *parser) simple() (Expr, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokLParen:
+		e, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokRParen {
+			return nil, fmt.Errorf("expected ')' at position %d", closing.pos)
+		}
+		return e, nil
+	case tokText:
+		return p.restriction(tok)
+	case tokEOF:
+		return nil, fmt.Errorf("unexpected end of filter")
+	default:
+		return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos)
+	}
+}
+
+func (p *parser) restriction(field token) (Expr, error) {
+	op := p.next()
+	if op.kind != tokOp {
+		return nil, fmt.Errorf("expected operator after %q at position %d", field.text, op.pos)
+	}
+
+	value := p.next()
+	if value.kind != tokText && value.kind != tokString {
+		return nil, fmt.Errorf("expected value after %q at position %d", op.text, value.pos)
+	}
+
+	return Restriction{
+		Field: field.text,
+		Op:    Operator(op.text),
+		Value: value.text,
+	}, nil
+}
+
+func startsTerm(tok token) bool {
+	switch tok.kind {
+	case tokText, tokLParen, tokNot, tokMinus:
+		return true
+	}
+	return false
+}
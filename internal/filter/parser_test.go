@@ -0,0 +1,122 @@
+package filter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  Expr
+	}{
+		{name: "empty", input: "  ", want: nil},
+		{
+			name:  "restriction",
+			input: "quantity >= 5",
+			want:  Restriction{Field: "quantity", Op: OpGe, Value: "5"},
+		},
+		{
+			name:  "operators without spaces",
+			input: "status!=SHIPPED",
+			want:  Restriction{Field: "status", Op: OpNe, Value: "SHIPPED"},
+		},
+		{
+			name:  "quoted value",
+			input: `item = "Gaming \"Pro\" Laptop"`,
+			want:  Restriction{Field: "item", Op: OpEq, Value: `Gaming "Pro" Laptop`},
+		},
+		{
+			name:  "has",
+			input: "labels.env:prod",
+			want:  Restriction{Field: "labels.env", Op: OpHas, Value: "prod"},
+		},
+		{
+			name:  "implicit and",
+			input: "item = a quantity > 1",
+			want: And{Exprs: []Expr{
+				Restriction{Field: "item", Op: OpEq, Value: "a"},
+				Restriction{Field: "quantity", Op: OpGt, Value: "1"},
+			}},
+		},
+		{
+			name:  "or binds tighter than and",
+			input: "a = 1 AND b = 2 OR c = 3",
+			want: And{Exprs: []Expr{
+				Restriction{Field: "a", Op: OpEq, Value: "1"},
+				Or{Exprs: []Expr{
+					Restriction{Field: "b", Op: OpEq, Value: "2"},
+					Restriction{Field: "c", Op: OpEq, Value: "3"},
+				}},
+			}},
+		},
+		{
+			name:  "parentheses",
+			input: "(a = 1 AND b = 2) OR c = 3",
+			want: Or{Exprs: []Expr{
+				And{Exprs: []Expr{
+					Restriction{Field: "a", Op: OpEq, Value: "1"},
+					Restriction{Field: "b", Op: OpEq, Value: "2"},
+				}},
+				Restriction{Field: "c", Op: OpEq, Value: "3"},
+			}},
+		},
+		{
+			name:  "not",
+			input: "NOT status = CANCELLED",
+			want:  Not{Expr: Restriction{Field: "status", Op: OpEq, Value: "CANCELLED"}},
+		},
+		{
+			name:  "minus",
+			input: "-(a = 1)",
+			want:  Not{Expr: Restriction{Field: "a", Op: OpEq, Value: "1"}},
+		},
+		{
+			name:  "negative value",
+			input: "quantity > -5",
+			want:  Restriction{Field: "quantity", Op: OpGt, Value: "-5"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.input, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse(%q) = %#v, want %#v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{name: "missing operator", input: "quantity 5", wantErr: `expected operator after "quantity"`},
+		{name: "missing value", input: "quantity >=", wantErr: `expected value after ">="`},
+		{name: "two operators", input: "quantity =< 5", wantErr: "expected value"},
+		{name: "unknown operator", input: "quantity ! 5", wantErr: `unknown operator "!"`},
+		{name: "unclosed parenthesis", input: "(a = 1", wantErr: "expected ')'"},
+		{name: "stray parenthesis", input: "a = 1)", wantErr: `unexpected ")"`},
+		{name: "unterminated string", input: `item = "abc`, wantErr: "unterminated string"},
+		{name: "dangling and", input: "a = 1 AND", wantErr: "unexpected end of filter"},
+		{name: "too long", input: "item = " + strings.Repeat("x", maxFilterLength), wantErr: "longer than"},
+		{name: "too deep", input: strings.Repeat("(", maxDepth+1) + "a = 1" + strings.Repeat(")", maxDepth+1), wantErr: "nested deeper"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.input)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Parse(%q) error = %v, want containing %q", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
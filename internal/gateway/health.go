@@ -0,0 +1,63 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"rpc/pkg/api/test"
+)
+
+// healthTimeout ограничивает запрос статуса к gRPC серверу.
+const healthTimeout = 2 * time.Second
+
+// registerHealth добавляет /healthz и /readyz. Оба спрашивают grpc.health.v1.Health
+// того же сервера, так что HTTP видит тот же статус, что и gRPC клиенты.
+// /healthz - живость: 200, пока gRPC сервер отвечает, даже если Postgres или Redis
+// недоступны (перезапуск процесса тут не поможет). /readyz - готовность: 200 только
+// в статусе SERVING у OrderService, иначе 503.
+func registerHealth(mux *runtime.ServeMux, conn grpc.ClientConnInterface) error {
+	client := healthpb.NewHealthClient(conn)
+
+	if err := mux.HandlePath(http.MethodGet, "/healthz", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		st, err := checkHealth(r.Context(), client, "")
+		if err != nil {
+			writeHealth(w, http.StatusServiceUnavailable, healthpb.HealthCheckResponse_UNKNOWN)
+			return
+		}
+		writeHealth(w, http.StatusOK, st)
+	}); err != nil {
+		return err
+	}
+
+	return mux.HandlePath(http.MethodGet, "/readyz", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		st, err := checkHealth(r.Context(), client, test.OrderService_ServiceDesc.ServiceName)
+		if err != nil || st != healthpb.HealthCheckResponse_SERVING {
+			writeHealth(w, http.StatusServiceUnavailable, st)
+			return
+		}
+		writeHealth(w, http.StatusOK, st)
+	})
+}
+
+func checkHealth(ctx context.Context, client healthpb.HealthClient, service string) (healthpb.HealthCheckResponse_ServingStatus, error) {
+	ctx, cancel := context.WithTimeout(ctx, healthTimeout)
+	defer cancel()
+
+	resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		return healthpb.HealthCheckResponse_UNKNOWN, err
+	}
+	return resp.Status, nil
+}
+
+func writeHealth(w http.ResponseWriter, code int, st healthpb.HealthCheckResponse_ServingStatus) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]string{"status": st.String()})
+}
@@ -3,11 +3,13 @@ package gateway
 import (
 	"context"
 	"net/http"
+	"net/textproto"
 	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 
 	"rpc/pkg/api/test"
 )
@@ -41,22 +43,62 @@ func loggingMiddleware(logger *zap.Logger, next http.HandlerFunc) http.HandlerFu
 	}
 }
 
-func StartGateway(ctx context.Context, grpcAddr string, httpAddr string, logger *zap.Logger) error {
-	mux := runtime.NewServeMux()
+// headerMatcher пробрасывает If-Match, Idempotency-Key и X-Actor в gRPC как метаданные
+// "if-match", "idempotency-key" и "x-actor", остальные заголовки - по правилам gateway по умолчанию.
+func headerMatcher(key string) (string, bool) {
+	switch textproto.CanonicalMIMEHeaderKey(key) {
+	case "If-Match":
+		return "if-match", true
+	case "Idempotency-Key":
+		return "idempotency-key", true
+	case "X-Actor":
+		return "x-actor", true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
 
-	opts := []grpc.DialOption{grpc.WithInsecure()}
+// etagHeader выставляет ETag, если в ответе есть заказ.
+func etagHeader(ctx context.Context, w http.ResponseWriter, resp proto.Message) error {
+	if r, ok := resp.(interface{ GetOrder() *test.Order }); ok && r.GetOrder() != nil {
+		if etag := r.GetOrder().GetEtag(); etag != "" {
+			w.Header().Set("ETag", etag)
+		}
+	}
+	return nil
+}
 
-	err := test.RegisterOrderServiceHandlerFromEndpoint(
-		ctx,
-		mux,
-		grpcAddr,
-		opts,
+// StartGateway обслуживает HTTP, пока не отменят ctx, и затем останавливается, давая
+// текущим запросам до shutdownTimeout на завершение. Соединение с gRPC закрывается
+// только после этого, чтобы незавершённые запросы успели получить ответ.
+func StartGateway(ctx context.Context, grpcAddr string, httpAddr string, shutdownTimeout time.Duration, logger *zap.Logger) error {
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(headerMatcher),
+		runtime.WithForwardResponseOption(etagHeader),
 	)
+
+	// одно соединение на все сервисы и проверки здоровья; закрывается после остановки HTTP
+	conn, err := grpc.NewClient(grpcAddr, grpc.WithInsecure())
 	if err != nil {
+		logger.Error("Failed to connect gateway to grpc server", zap.Error(err))
+		return err
+	}
+	defer conn.Close()
+
+	if err := test.RegisterOrderServiceHandler(ctx, mux, conn); err != nil {
 		logger.Error("Failed to register gateway", zap.Error(err))
 		return err
 	}
 
+	if err := test.RegisterInventoryServiceHandler(ctx, mux, conn); err != nil {
+		logger.Error("Failed to register inventory gateway", zap.Error(err))
+		return err
+	}
+
+	if err := registerHealth(mux, conn); err != nil {
+		logger.Error("Failed to register health endpoints", zap.Error(err))
+		return err
+	}
+
 	wrappedMux := wrapLogging(mux, logger)
 
 	logger.Info("Gateway started successfully",
@@ -64,11 +106,35 @@ func StartGateway(ctx context.Context, grpcAddr string, httpAddr string, logger
 		zap.String("grpc_addr", grpcAddr),
 	)
 
-	return http.ListenAndServe(httpAddr, wrappedMux)
+	srv := &http.Server{Addr: httpAddr, Handler: wrappedMux}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		logger.Warn("Gateway forced shutdown", zap.Error(err))
+		return srv.Close()
+	}
+	logger.Info("Gateway stopped")
+	return nil
 }
 
 func wrapLogging(mux *runtime.ServeMux, logger *zap.Logger) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/healthz" || r.URL.Path == "/readyz" {
+			mux.ServeHTTP(w, r)
+			return
+		}
 		loggingMiddleware(logger, mux.ServeHTTP)(w, r)
 	})
 }
@@ -0,0 +1,99 @@
+// Package health следит за зависимостями сервера (Postgres, Redis) и держит по
+// ним статус в стандартном сервисе grpc.health.v1.Health.
+package health
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// pingTimeout ограничивает одну проверку зависимости.
+const pingTimeout = 2 * time.Second
+
+// Check - проверка одной зависимости: Ping возвращает ошибку, если она недоступна.
+type Check struct {
+	Name string
+	Ping func(ctx context.Context) error
+}
+
+// Checker периодически проверяет зависимости и переключает services между
+// SERVING (все проверки прошли) и NOT_SERVING. Общий статус сервера ("")
+// следует за ними.
+type Checker struct {
+	server   *health.Server
+	services []string
+	checks   []Check
+	interval time.Duration
+	logger   *zap.Logger
+}
+
+// NewChecker до первой проверки считает services недоступными.
+func NewChecker(server *health.Server, services []string, interval time.Duration, logger *zap.Logger, checks ...Check) *Checker {
+	c := &Checker{
+		server:   server,
+		services: append([]string{""}, services...),
+		checks:   checks,
+		interval: interval,
+		logger:   logger,
+	}
+	c.set(healthpb.HealthCheckResponse_NOT_SERVING)
+	return c
+}
+
+// Run проверяет зависимости сразу и затем раз в interval, пока не отменят ctx.
+func (c *Checker) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	serving := false
+	first := true
+	for {
+		ok := c.check(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if ok != serving || first {
+			if ok {
+				c.set(healthpb.HealthCheckResponse_SERVING)
+				c.logger.Info("dependencies are healthy, serving")
+			} else {
+				c.set(healthpb.HealthCheckResponse_NOT_SERVING)
+				c.logger.Warn("dependencies are unhealthy, not serving")
+			}
+			serving, first = ok, false
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check пингует все зависимости и пишет в лог те, что не ответили.
+func (c *Checker) check(ctx context.Context) bool {
+	ok := true
+	for _, check := range c.checks {
+		pingCtx, cancel := context.WithTimeout(ctx, min(pingTimeout, c.interval))
+		err := check.Ping(pingCtx)
+		cancel()
+		if err != nil {
+			ok = false
+			if ctx.Err() == nil {
+				c.logger.Warn("health check failed", zap.String("check", check.Name), zap.Error(err))
+			}
+		}
+	}
+	return ok
+}
+
+func (c *Checker) set(st healthpb.HealthCheckResponse_ServingStatus) {
+	for _, service := range c.services {
+		c.server.SetServingStatus(service, st)
+	}
+}
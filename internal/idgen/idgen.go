@@ -0,0 +1,101 @@
+// Package idgen выдаёт id новых заказов.
+package idgen
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+)
+
+// IDGenerator выдаёт id нового заказа. requested - id, который предложил клиент
+// (order_id в запросе, может быть пустым). Ошибка относится к requested.
+type IDGenerator interface {
+	NewID(requested string) (string, error)
+}
+
+// Стратегии для ORDER_ID_STRATEGY.
+const (
+	StrategyUUIDv4 = "uuidv4"
+	StrategyUUIDv7 = "uuidv7"
+	StrategyULID   = "ulid"
+	StrategyClient = "client"
+)
+
+// New выбирает генератор по имени стратегии. Для client id без order_id
+// генерируются как UUIDv7.
+func New(strategy string) (IDGenerator, error) {
+	switch strategy {
+	case StrategyUUIDv4:
+		return NewUUIDv4(), nil
+	case StrategyUUIDv7:
+		return NewUUIDv7(), nil
+	case StrategyULID:
+		return NewULID(), nil
+	case StrategyClient:
+		return NewClientSupplied(NewUUIDv7()), nil
+	}
+	return nil, fmt.Errorf("unknown id strategy %q", strategy)
+}
+
+var errNotAccepted = errors.New("value must be empty: order ids are generated by the server")
+
+type generated func() (string, error)
+
+func (g generated) NewID(requested string) (string, error) {
+	if requested != "" {
+		return "", errNotAccepted
+	}
+	return g()
+}
+
+// NewUUIDv4 - случайные UUID. Вставки разбрасываются по всему индексу первичного ключа.
+func NewUUIDv4() IDGenerator {
+	return generated(func() (string, error) {
+		return uuid.New().String(), nil
+	})
+}
+
+// NewUUIDv7 - UUID с временем создания в старших битах: новые id растут,
+// и вставки идут в конец индекса.
+func NewUUIDv7() IDGenerator {
+	return generated(func() (string, error) {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return "", fmt.Errorf("generate uuidv7: %w", err)
+		}
+		return id.String(), nil
+	})
+}
+
+// NewULID - 26 символов Crockford base32, упорядочены по времени, монотонны в пределах миллисекунды.
+func NewULID() IDGenerator {
+	return generated(func() (string, error) {
+		return ulid.Make().String(), nil
+	})
+}
+
+// clientIDPattern совпадает с правилом order_id в api/order.proto, плюс непустота.
+var clientIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,36}$`)
+
+type clientSupplied struct {
+	fallback IDGenerator
+}
+
+// NewClientSupplied принимает id клиента, если он есть, иначе берёт id из fallback.
+// Занятый id отклоняет уже база (AlreadyExists).
+func NewClientSupplied(fallback IDGenerator) IDGenerator {
+	return &clientSupplied{fallback: fallback}
+}
+
+func (c *clientSupplied) NewID(requested string) (string, error) {
+	if requested == "" {
+		return c.fallback.NewID("")
+	}
+	if !clientIDPattern.MatchString(requested) {
+		return "", errors.New("value must be 1-36 letters, digits, '-' or '_'")
+	}
+	return requested, nil
+}
@@ -0,0 +1,86 @@
+package idgen
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var (
+	uuidV4Pattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	uuidV7Pattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	ulidPattern   = regexp.MustCompile(`^[0-9A-HJKMNP-TV-Z]{26}$`)
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		strategy  string
+		requested string
+		want      *regexp.Regexp
+		wantErr   string
+	}{
+		{strategy: StrategyUUIDv4, want: uuidV4Pattern},
+		{strategy: StrategyUUIDv7, want: uuidV7Pattern},
+		{strategy: StrategyULID, want: ulidPattern},
+		{strategy: StrategyClient, want: uuidV7Pattern},
+		{strategy: StrategyClient, requested: "order_42-a", want: regexp.MustCompile(`^order_42-a$`)},
+		{strategy: StrategyClient, requested: "order 42", wantErr: "letters, digits"},
+		{strategy: StrategyClient, requested: strings.Repeat("a", 37), wantErr: "1-36"},
+		{strategy: StrategyUUIDv4, requested: "order-42", wantErr: "generated by the server"},
+		{strategy: StrategyUUIDv7, requested: "order-42", wantErr: "generated by the server"},
+		{strategy: StrategyULID, requested: "order-42", wantErr: "generated by the server"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.strategy+"/"+tt.requested, func(t *testing.T) {
+			gen, err := New(tt.strategy)
+			if err != nil {
+				t.Fatalf("New(%q) error: %v", tt.strategy, err)
+			}
+
+			id, err := gen.NewID(tt.requested)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("NewID(%q) = %q, %v, want error containing %q", tt.requested, id, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewID(%q) error: %v", tt.requested, err)
+			}
+			if !tt.want.MatchString(id) {
+				t.Errorf("NewID(%q) = %q, want match %s", tt.requested, id, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewUnknownStrategy(t *testing.T) {
+	if _, err := New("serial"); err == nil {
+		t.Fatal("New(\"serial\") error = nil, want error")
+	}
+}
+
+// id стратегий uuidv7 и ulid растут со временем, поэтому вставки идут в конец индекса.
+func TestOrderedStrategies(t *testing.T) {
+	for _, strategy := range []string{StrategyUUIDv7, StrategyULID} {
+		t.Run(strategy, func(t *testing.T) {
+			gen, err := New(strategy)
+			if err != nil {
+				t.Fatalf("New(%q) error: %v", strategy, err)
+			}
+
+			prev, _ := gen.NewID("")
+			for i := 0; i < 1000; i++ {
+				id, err := gen.NewID("")
+				if err != nil {
+					t.Fatalf("NewID error: %v", err)
+				}
+				if id <= prev {
+					t.Fatalf("id %q is not greater than previous %q", id, prev)
+				}
+				prev = id
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package interceptor
+
+import (
+	"context"
+	"unicode/utf8"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"rpc/internal/repository"
+)
+
+// actorKey - ключ метаданных с автором изменений; gateway кладёт туда заголовок X-Actor.
+const actorKey = "x-actor"
+
+// maxActorLen - длина колонки order_events.actor.
+const maxActorLen = 255
+
+func withActor(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	values := md.Get(actorKey)
+	if len(values) == 0 || values[0] == "" {
+		return ctx
+	}
+	actor := values[0]
+	if utf8.RuneCountInString(actor) > maxActorLen {
+		actor = string([]rune(actor)[:maxActorLen])
+	}
+	return repository.WithActor(ctx, actor)
+}
+
+// Actor передаёт автора изменений из метаданных x-actor в контекст обработчика,
+// откуда репозиторий пишет его в историю заказа.
+func Actor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(withActor(ctx), req)
+	}
+}
+
+func ActorStream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &actorStream{ServerStream: ss, ctx: withActor(ss.Context())})
+	}
+}
+
+type actorStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *actorStream) Context() context.Context {
+	return s.ctx
+}
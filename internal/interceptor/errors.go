@@ -0,0 +1,27 @@
+package interceptor
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"rpc/internal/apierror"
+)
+
+// Errors приводит любую ошибку обработчика к ошибке API: с google.rpc.ErrorInfo
+// и без внутренних подробностей в сообщении. Исходная ошибка остаётся в err.Error()
+// для ZapLog, поэтому Errors ставится после него.
+func Errors() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return nil, apierror.From(err, "")
+		}
+		return resp, nil
+	}
+}
+
+func ErrorsStream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return apierror.From(handler(srv, ss), "")
+	}
+}
@@ -0,0 +1,59 @@
+package interceptor
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"rpc/internal/apierror"
+	"rpc/internal/validate"
+)
+
+// Validate отклоняет запросы, нарушающие правила buf.validate из api/order.proto,
+// с InvalidArgument и google.rpc.BadRequest до вызова обработчика.
+func Validate() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if msg, ok := req.(proto.Message); ok {
+			if err := check(msg); err != nil {
+				return nil, err
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// ValidateStream проверяет единственный запрос серверных потоков. Клиентские потоки
+// (ImportOrders) проверяют записи сами: одна плохая запись не должна рвать весь поток.
+func ValidateStream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if info.IsClientStream {
+			return handler(srv, ss)
+		}
+		return handler(srv, &validatedStream{ServerStream: ss})
+	}
+}
+
+func check(msg proto.Message) error {
+	violations, err := validate.Message(msg)
+	if err != nil {
+		return apierror.From(err, "validate request")
+	}
+	if len(violations) > 0 {
+		return validate.Error(violations...)
+	}
+	return nil
+}
+
+type validatedStream struct {
+	grpc.ServerStream
+}
+
+func (s *validatedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if msg, ok := m.(proto.Message); ok {
+		return check(msg)
+	}
+	return nil
+}
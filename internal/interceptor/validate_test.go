@@ -0,0 +1,100 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"rpc/pkg/api/test"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		req       interface{}
+		wantCode  codes.Code
+		wantField string
+	}{
+		{name: "valid", req: &test.GetOrderRequest{Id: "order-1"}},
+		{name: "invalid", req: &test.GetOrderRequest{}, wantCode: codes.InvalidArgument, wantField: "id"},
+		{
+			name:      "nested",
+			req:       &test.CreateOrderRequest{LineItems: []*test.LineItem{{Item: "Laptop"}}},
+			wantCode:  codes.InvalidArgument,
+			wantField: "line_items[0].quantity",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				return req, nil
+			}
+
+			_, err := Validate()(context.Background(), tt.req, &grpc.UnaryServerInfo{}, handler)
+			st := status.Convert(err)
+			if st.Code() != tt.wantCode {
+				t.Fatalf("Validate() code = %s, want %s (%v)", st.Code(), tt.wantCode, err)
+			}
+			if called != (tt.wantCode == codes.OK) {
+				t.Errorf("handler called = %v, want %v", called, tt.wantCode == codes.OK)
+			}
+			if tt.wantField == "" {
+				return
+			}
+			details := st.Details()
+			if len(details) != 1 {
+				t.Fatalf("details = %v, want one BadRequest", details)
+			}
+			br, ok := details[0].(*errdetails.BadRequest)
+			if !ok || len(br.FieldViolations) == 0 || br.FieldViolations[0].Field != tt.wantField {
+				t.Errorf("details = %v, want violation of %s", details[0], tt.wantField)
+			}
+		})
+	}
+}
+
+// recvStream отдаёт один заранее заданный запрос.
+type recvStream struct {
+	grpc.ServerStream
+	req proto.Message
+}
+
+func (s *recvStream) Context() context.Context { return context.Background() }
+
+func (s *recvStream) RecvMsg(m interface{}) error {
+	proto.Merge(m.(proto.Message), s.req)
+	return nil
+}
+
+func TestValidateStream(t *testing.T) {
+	tests := []struct {
+		name         string
+		clientStream bool
+		req          proto.Message
+		wantCode     codes.Code
+	}{
+		{name: "valid", req: &test.StreamOrdersRequest{Filter: "quantity > 1"}},
+		{name: "invalid", req: &test.WatchOrdersRequest{ResumeToken: "abc"}, wantCode: codes.InvalidArgument},
+		{name: "client stream is left to the handler", clientStream: true, req: &test.WatchOrdersRequest{ResumeToken: "abc"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := func(srv interface{}, stream grpc.ServerStream) error {
+				return stream.RecvMsg(tt.req.ProtoReflect().Type().New().Interface())
+			}
+
+			err := ValidateStream()(nil, &recvStream{req: tt.req}, &grpc.StreamServerInfo{IsClientStream: tt.clientStream}, handler)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("ValidateStream() code = %s, want %s (%v)", code, tt.wantCode, err)
+			}
+		})
+	}
+}
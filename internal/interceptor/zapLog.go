@@ -2,14 +2,22 @@ package interceptor
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 )
 
+// healthMethods - префикс методов grpc.health.v1.Health: пробы приходят каждые
+// несколько секунд и забили бы лог, а Watch держит поток всё время работы клиента.
+const healthMethods = "/grpc.health.v1.Health/"
+
 func ZapLog(logger *zap.Logger) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if strings.HasPrefix(info.FullMethod, healthMethods) {
+			return handler(ctx, req)
+		}
 
 		startTime := time.Now()
 		logger.Info("requested",
@@ -37,3 +45,32 @@ func ZapLog(logger *zap.Logger) grpc.UnaryServerInterceptor {
 		return resp, err
 	}
 }
+
+func ZapLogStream(logger *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if strings.HasPrefix(info.FullMethod, healthMethods) {
+			return handler(srv, ss)
+		}
+
+		startTime := time.Now()
+		logger.Info("stream opened", zap.String("method", info.FullMethod))
+
+		err := handler(srv, ss)
+
+		duration := time.Since(startTime)
+		if err != nil {
+			logger.Error("stream failed",
+				zap.String("method", info.FullMethod),
+				zap.Error(err),
+				zap.Duration("duration", duration),
+			)
+		} else {
+			logger.Info("stream closed",
+				zap.String("method", info.FullMethod),
+				zap.Duration("duration", duration),
+			)
+		}
+
+		return err
+	}
+}
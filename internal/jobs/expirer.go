@@ -0,0 +1,69 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"rpc/internal/repository"
+)
+
+const expireBatchSize = 100
+
+// expiryActor - автор отмен по сроку в истории заказов.
+const expiryActor = "system:expiry"
+
+// Expirer периодически отменяет заказы, которые не подтвердили до expire_time.
+type Expirer struct {
+	repo     repository.OrderExpirer
+	interval time.Duration
+	logger   *zap.Logger
+}
+
+func NewExpirer(repo repository.OrderExpirer, interval time.Duration, logger *zap.Logger) *Expirer {
+	return &Expirer{
+		repo:     repo,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+// Run отменяет просроченные заказы сразу и затем раз в interval, пока не отменят ctx.
+// Отмена ctx прерывает и текущую пачку: её транзакция откатывается целиком.
+func (e *Expirer) Run(ctx context.Context) {
+	ctx = repository.WithActor(ctx, expiryActor)
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		e.expire(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// expire отменяет пачки, пока они полные; несколько серверов делят работу через SKIP LOCKED.
+func (e *Expirer) expire(ctx context.Context) {
+	var total int
+	for ctx.Err() == nil {
+		orders, err := e.repo.ExpirePending(ctx, expireBatchSize)
+		if err != nil {
+			if ctx.Err() == nil {
+				e.logger.Error("expire pending orders failed", zap.Error(err))
+			}
+			break
+		}
+		total += len(orders)
+		if len(orders) < expireBatchSize {
+			break
+		}
+	}
+	if total > 0 {
+		e.logger.Info("cancelled expired orders", zap.Int("count", total))
+	}
+}
@@ -0,0 +1,96 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"rpc/internal/repository"
+)
+
+const purgeBatchSize = 500
+
+// Purger периодически окончательно удаляет заказы, мягко удалённые дольше retention назад,
+// просроченные ключи идемпотентности и записи журнала изменений старше changesRetention.
+type Purger struct {
+	repo             repository.OrderPurger
+	retention        time.Duration
+	changesRetention time.Duration
+	interval         time.Duration
+	logger           *zap.Logger
+}
+
+func NewPurger(repo repository.OrderPurger, retention, changesRetention, interval time.Duration, logger *zap.Logger) *Purger {
+	return &Purger{
+		repo:             repo,
+		retention:        retention,
+		changesRetention: changesRetention,
+		interval:         interval,
+		logger:           logger,
+	}
+}
+
+// Run чистит заказы сразу и затем раз в interval, пока не отменят ctx.
+func (p *Purger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.purge(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *Purger) purge(ctx context.Context) {
+	orders := p.drain(ctx, func() (int64, error) {
+		return p.repo.PurgeDeleted(ctx, p.retention, purgeBatchSize)
+	})
+	if orders > 0 {
+		p.logger.Info("purged deleted orders",
+			zap.Int64("count", orders),
+			zap.Duration("retention", p.retention),
+		)
+	}
+
+	keys := p.drain(ctx, func() (int64, error) {
+		return p.repo.PurgeIdempotencyKeys(ctx, purgeBatchSize)
+	})
+	if keys > 0 {
+		p.logger.Info("purged expired idempotency keys", zap.Int64("count", keys))
+	}
+
+	changes := p.drain(ctx, func() (int64, error) {
+		return p.repo.PurgeChanges(ctx, p.changesRetention, purgeBatchSize)
+	})
+	if changes > 0 {
+		p.logger.Info("purged old order changes",
+			zap.Int64("count", changes),
+			zap.Duration("retention", p.changesRetention),
+		)
+	}
+}
+
+// drain вызывает batch, пока тот удаляет полные пачки, чтобы не держать
+// долгие блокировки на большой таблице.
+func (p *Purger) drain(ctx context.Context, batch func() (int64, error)) int64 {
+	var total int64
+	for ctx.Err() == nil {
+		n, err := batch()
+		if err != nil {
+			if ctx.Err() == nil {
+				p.logger.Error("purge failed", zap.Error(err))
+			}
+			break
+		}
+		total += n
+		if n < purgeBatchSize {
+			break
+		}
+	}
+	return total
+}
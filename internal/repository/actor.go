@@ -0,0 +1,16 @@
+package repository
+
+import "context"
+
+type actorKey struct{}
+
+// WithActor запоминает, от чьего имени меняются заказы; репозиторий пишет это в историю.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFrom возвращает автора изменений из контекста или "", если он неизвестен.
+func ActorFrom(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
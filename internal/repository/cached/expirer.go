@@ -0,0 +1,32 @@
+package cached
+
+import (
+	"context"
+
+	"rpc/internal/repository"
+	"rpc/pkg/api/test"
+)
+
+// cachedExpirer сбрасывает кэш отменённых по сроку заказов, как SetStatus.
+type cachedExpirer struct {
+	cache   *cachedRepository
+	expirer repository.OrderExpirer
+}
+
+func NewCachedExpirer(redisRepo repository.OrderRepository, expirer repository.OrderExpirer) repository.OrderExpirer {
+	return &cachedExpirer{
+		cache:   &cachedRepository{redisRepo: redisRepo},
+		expirer: expirer,
+	}
+}
+
+func (c *cachedExpirer) ExpirePending(ctx context.Context, limit int) ([]*test.Order, error) {
+	orders, err := c.expirer.ExpirePending(ctx, limit)
+	for _, order := range orders {
+		c.cache.redisRepo.Delete(ctx, order.Id, 0)
+	}
+	if len(orders) > 0 {
+		c.cache.invalidateLists(ctx)
+	}
+	return orders, err
+}
@@ -2,6 +2,8 @@ package cached
 
 import (
 	"context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"rpc/internal/repository"
 	"rpc/pkg/api/test"
 )
@@ -18,15 +20,41 @@ func NewCachedRepository(redisRepo, pgRepo repository.OrderRepository) repositor
 	}
 }
 
+// invalidateLists сбрасывает все закэшированные страницы списков, если кэш это умеет.
+// Любая запись может сдвинуть любую страницу ListOrders, поэтому сбрасываются все.
+func (c *cachedRepository) invalidateLists(ctx context.Context) {
+	if redisRepo, ok := c.redisRepo.(interface {
+		InvalidateLists(ctx context.Context) error
+	}); ok {
+		redisRepo.InvalidateLists(ctx)
+	}
+}
+
+// listCache - кэш, который умеет хранить страницы списков.
+type listCache interface {
+	ListGeneration(ctx context.Context) (int64, error)
+	SaveList(ctx context.Context, opts repository.ListOptions, generation int64, orders []*test.Order, nextPageToken string) error
+}
+
 func (c *cachedRepository) Create(ctx context.Context, order *test.Order) error {
 	err := c.pgRepo.Create(ctx, order)
 	if err == nil {
 		// Инвалидируем кэш при создании
-		c.redisRepo.Delete(ctx, order.Id)
+		c.redisRepo.Delete(ctx, order.Id, 0)
+		c.invalidateLists(ctx)
 	}
 	return err
 }
 
+func (c *cachedRepository) CreateWithKey(ctx context.Context, order *test.Order, key repository.IdempotencyKey) (string, error) {
+	id, err := c.pgRepo.CreateWithKey(ctx, order, key)
+	if err == nil {
+		c.redisRepo.Delete(ctx, id, 0)
+		c.invalidateLists(ctx)
+	}
+	return id, err
+}
+
 func (c *cachedRepository) Get(ctx context.Context, id string) (*test.Order, error) {
 
 	if order, err := c.redisRepo.Get(ctx, id); err == nil {
@@ -42,40 +70,185 @@ func (c *cachedRepository) Get(ctx context.Context, id string) (*test.Order, err
 	return order, nil
 }
 
-func (c *cachedRepository) Update(ctx context.Context, order *test.Order) error {
-	err := c.pgRepo.Update(ctx, order)
-	if err == nil {
+// invalidates сообщает, нужно ли после записи сбросить кэш заказа: при успехе
+// и при Aborted, который значит, что версия, прочитанная через Get, уже устарела.
+func invalidates(err error) bool {
+	return err == nil || status.Code(err) == codes.Aborted
+}
+
+func (c *cachedRepository) Update(ctx context.Context, order *test.Order, fields []string) error {
+	err := c.pgRepo.Update(ctx, order, fields)
+	if invalidates(err) {
+
+		c.redisRepo.Delete(ctx, order.Id, 0)
+		c.invalidateLists(ctx)
+	}
+	return err
+}
 
-		c.redisRepo.Delete(ctx, order.Id)
+func (c *cachedRepository) SetStatus(ctx context.Context, order *test.Order, to test.OrderStatus) error {
+	err := c.pgRepo.SetStatus(ctx, order, to)
+	if invalidates(err) || status.Code(err) == codes.FailedPrecondition {
+
+		c.redisRepo.Delete(ctx, order.Id, 0)
+		c.invalidateLists(ctx)
 	}
 	return err
 }
 
-func (c *cachedRepository) Delete(ctx context.Context, id string) error {
-	err := c.pgRepo.Delete(ctx, id)
+func (c *cachedRepository) Delete(ctx context.Context, id string, version int64) error {
+	err := c.pgRepo.Delete(ctx, id, version)
+	if invalidates(err) {
+
+		c.redisRepo.Delete(ctx, id, 0)
+		c.invalidateLists(ctx)
+	}
+	return err
+}
+
+func (c *cachedRepository) Undelete(ctx context.Context, id string) error {
+	err := c.pgRepo.Undelete(ctx, id)
 	if err == nil {
 
-		c.redisRepo.Delete(ctx, id)
+		c.redisRepo.Delete(ctx, id, 0)
+		c.invalidateLists(ctx)
 	}
 	return err
 }
 
-func (c *cachedRepository) List(ctx context.Context) ([]*test.Order, error) {
+func (c *cachedRepository) List(ctx context.Context, opts repository.ListOptions) ([]*test.Order, string, error) {
+
+	if orders, next, err := c.redisRepo.List(ctx, opts); err == nil {
+		return orders, next, nil
+	}
 
-	if orders, err := c.redisRepo.List(ctx); err == nil {
-		return orders, nil
+	// поколение берётся до чтения из базы: если запись успеет его сменить,
+	// страница ляжет под старый ключ и читать её уже никто не будет
+	lists, canSave := c.redisRepo.(listCache)
+	var generation int64
+	if canSave {
+		var err error
+		generation, err = lists.ListGeneration(ctx)
+		canSave = err == nil
 	}
 
-	orders, err := c.pgRepo.List(ctx)
+	orders, next, err := c.pgRepo.List(ctx, opts)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if canSave {
+		lists.SaveList(ctx, opts, generation, orders, next)
+	}
+
+	return orders, next, nil
+}
+
+// History идёт мимо кэша: история только дописывается, и читать её нужно свежей.
+func (c *cachedRepository) History(ctx context.Context, opts repository.HistoryOptions) ([]*test.OrderHistoryEntry, string, error) {
+	return c.pgRepo.History(ctx, opts)
+}
+
+// Stats берёт статистику из кэша, пока она не истекла; записи заказов её не сбрасывают.
+func (c *cachedRepository) Stats(ctx context.Context, opts repository.StatsOptions) (*test.OrderStats, error) {
+	if stats, err := c.redisRepo.Stats(ctx, opts); err == nil {
+		return stats, nil
+	}
+
+	stats, err := c.pgRepo.Stats(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
 
 	if redisRepo, ok := c.redisRepo.(interface {
-		SaveList(ctx context.Context, orders []*test.Order) error
+		SaveStats(ctx context.Context, opts repository.StatsOptions, stats *test.OrderStats) error
 	}); ok {
-		redisRepo.SaveList(ctx, orders)
+		redisRepo.SaveStats(ctx, opts, stats)
+	}
+
+	return stats, nil
+}
+
+func (c *cachedRepository) BatchCreate(ctx context.Context, orders []*test.Order) error {
+	err := c.pgRepo.BatchCreate(ctx, orders)
+	if err == nil {
+		c.redisRepo.BatchDelete(ctx, orderIDs(orders))
+		c.invalidateLists(ctx)
+	}
+	return err
+}
+
+func (c *cachedRepository) BatchGet(ctx context.Context, ids []string) ([]*test.Order, error) {
+	redisRepo, ok := c.redisRepo.(interface {
+		GetMany(ctx context.Context, ids []string) (map[string]*test.Order, error)
+	})
+	if !ok {
+		return c.pgRepo.BatchGet(ctx, ids)
 	}
 
+	found, err := redisRepo.GetMany(ctx, ids)
+	if err != nil {
+		found = make(map[string]*test.Order)
+	}
+
+	var misses []string
+	for _, id := range ids {
+		if found[id] == nil {
+			misses = append(misses, id)
+		}
+	}
+
+	if len(misses) > 0 {
+		loaded, err := c.pgRepo.BatchGet(ctx, misses)
+		if err != nil {
+			return nil, err
+		}
+		for _, order := range loaded {
+			found[order.Id] = order
+		}
+		c.redisRepo.BatchCreate(ctx, loaded)
+	}
+
+	orders := make([]*test.Order, len(ids))
+	for i, id := range ids {
+		orders[i] = found[id]
+	}
 	return orders, nil
 }
+
+func (c *cachedRepository) BatchDelete(ctx context.Context, ids []string) error {
+	err := c.pgRepo.BatchDelete(ctx, ids)
+	if err == nil {
+		c.redisRepo.BatchDelete(ctx, ids)
+		c.invalidateLists(ctx)
+	}
+	return err
+}
+
+// Stream идёт мимо кэша: выгрузку целиком в Redis класть незачем.
+func (c *cachedRepository) Stream(ctx context.Context, opts repository.ListOptions, send func(*test.Order) error) error {
+	return c.pgRepo.Stream(ctx, opts, send)
+}
+
+// Search идёт мимо кэша: страницы поиска зависят от ранга и быстро устаревают.
+func (c *cachedRepository) Search(ctx context.Context, opts repository.SearchOptions) ([]*test.Order, string, error) {
+	return c.pgRepo.Search(ctx, opts)
+}
+
+// Import не трогает кэш заказов: у импортированных заказов новые id.
+// Сбрасываются только страницы списков.
+func (c *cachedRepository) Import(ctx context.Context, orders []*test.Order) error {
+	err := c.pgRepo.Import(ctx, orders)
+	if err == nil {
+		c.invalidateLists(ctx)
+	}
+	return err
+}
+
+func orderIDs(orders []*test.Order) []string {
+	ids := make([]string, len(orders))
+	for i, order := range orders {
+		ids[i] = order.Id
+	}
+	return ids
+}
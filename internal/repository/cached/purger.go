@@ -0,0 +1,30 @@
+package cached
+
+import (
+	"context"
+	"time"
+
+	"rpc/internal/repository"
+)
+
+// cachedPurger сбрасывает страницы списков, когда из базы пропадают мягко удалённые
+// заказы: они видны в страницах с show_deleted.
+type cachedPurger struct {
+	repository.OrderPurger
+	cache *cachedRepository
+}
+
+func NewCachedPurger(redisRepo repository.OrderRepository, purger repository.OrderPurger) repository.OrderPurger {
+	return &cachedPurger{
+		OrderPurger: purger,
+		cache:       &cachedRepository{redisRepo: redisRepo},
+	}
+}
+
+func (c *cachedPurger) PurgeDeleted(ctx context.Context, olderThan time.Duration, limit int) (int64, error) {
+	n, err := c.OrderPurger.PurgeDeleted(ctx, olderThan, limit)
+	if n > 0 {
+		c.cache.invalidateLists(ctx)
+	}
+	return n, err
+}
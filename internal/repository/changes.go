@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+type ChangeType string
+
+const (
+	ChangeCreated ChangeType = "CREATED"
+	ChangeUpdated ChangeType = "UPDATED"
+	ChangeDeleted ChangeType = "DELETED"
+)
+
+// Position - место записи в журнале изменений: транзакция, которая её записала, и
+// номер записи. Читатели получают только записи, все транзакции до которых уже
+// завершены, поэтому позиции приходят строго по возрастанию и служат токеном
+// возобновления для WatchOrders. Нулевая позиция - начало журнала.
+type Position struct {
+	TxID int64
+	Seq  int64
+}
+
+// Before сообщает, идёт ли p в журнале раньше other.
+func (p Position) Before(other Position) bool {
+	if p.TxID != other.TxID {
+		return p.TxID < other.TxID
+	}
+	return p.Seq < other.Seq
+}
+
+func (p Position) IsZero() bool {
+	return p == Position{}
+}
+
+// Change - запись журнала изменений заказов.
+type Change struct {
+	Position Position
+	OrderID  string
+	Type     ChangeType
+	Time     time.Time
+}
+
+// ChangeLog - журнал изменений заказов и подписка на новые записи.
+type ChangeLog interface {
+	// After возвращает до limit изменений после pos в порядке позиций. Записи
+	// транзакций, до которых ещё не завершены более ранние, не возвращаются.
+	After(ctx context.Context, pos Position, limit int) ([]Change, error)
+	// Purged возвращает позицию последней записи, удалённой очисткой,
+	// нулевую, если журнал ещё не чистили.
+	Purged(ctx context.Context) (Position, error)
+	// Latest возвращает позицию последней записи, которую уже отдаёт After,
+	// даже если её удалили очисткой.
+	Latest(ctx context.Context) (Position, error)
+	// Listen блокируется и вызывает wake после каждой транзакции, записавшей
+	// изменения, пока не отменят ctx или не оборвётся соединение. ready вызывается,
+	// когда подписка уже действует: записи после этого момента не потеряются.
+	Listen(ctx context.Context, ready func(), wake func()) error
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// Типы ресурсов в google.rpc.ResourceInfo ошибок NotFound; по ним apierror выбирает reason.
+const (
+	OrderResource = "api.Order"
+	StockResource = "api.Stock"
+)
+
+// OrderNotFound - codes.NotFound с google.rpc.ResourceInfo заказа.
+func OrderNotFound(id string) error {
+	return notFound(fmt.Sprintf("order with id %s not found", id), OrderResource, id)
+}
+
+// StockNotFound - codes.NotFound с google.rpc.ResourceInfo остатка позиции.
+func StockNotFound(item string) error {
+	return notFound(fmt.Sprintf("stock of item %q not found", item), StockResource, item)
+}
+
+func notFound(msg, resourceType, name string) error {
+	st := status.New(codes.NotFound, msg)
+	if withDetails, err := st.WithDetails(&errdetails.ResourceInfo{ResourceType: resourceType, ResourceName: name, Description: msg}); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// OrdersNotFound - ошибка пакетной операции: codes.NotFound, где для каждого
+// отсутствующего заказа в деталях лежит свой google.rpc.ResourceInfo.
+func OrdersNotFound(ids []string) error {
+	st := status.New(codes.NotFound, fmt.Sprintf("orders not found: %s", strings.Join(ids, ", ")))
+
+	details := make([]protoadapt.MessageV1, 0, len(ids))
+	for _, id := range ids {
+		details = append(details, &errdetails.ResourceInfo{
+			ResourceType: OrderResource,
+			ResourceName: id,
+			Description:  "order not found",
+		})
+	}
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		st = withDetails
+	}
+
+	return st.Err()
+}
+
+// StockShortage - нехватка остатка одной позиции при резервировании.
+type StockShortage struct {
+	Item      string
+	Requested int64
+	Available int64
+}
+
+// InsufficientStock - ошибка резервирования: codes.ResourceExhausted, где для каждой
+// позиции, которой не хватает, в деталях лежит нарушение google.rpc.QuotaFailure.
+func InsufficientStock(shortages []StockShortage) error {
+	items := make([]string, len(shortages))
+	failure := &errdetails.QuotaFailure{}
+	for i, s := range shortages {
+		items[i] = s.Item
+		failure.Violations = append(failure.Violations, &errdetails.QuotaFailure_Violation{
+			Subject:     "item:" + s.Item,
+			Description: fmt.Sprintf("requested %d, available %d", s.Requested, s.Available),
+		})
+	}
+
+	st := status.New(codes.ResourceExhausted, fmt.Sprintf("insufficient stock: %s", strings.Join(items, ", ")))
+	if withDetails, err := st.WithDetails(failure); err == nil {
+		st = withDetails
+	}
+
+	return st.Err()
+}
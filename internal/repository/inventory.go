@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"rpc/pkg/api/test"
+)
+
+// StockListOptions описывает страницу ListStock; PageSize уже нормализован.
+type StockListOptions struct {
+	PageSize  int32
+	PageToken string
+}
+
+// InventoryRepository хранит остатки позиций. Резервы под заказы ставит и снимает
+// OrderRepository в транзакции самого заказа, здесь они только видны в Stock.Reserved.
+// Неизвестная позиция - codes.NotFound.
+type InventoryRepository interface {
+	GetStock(ctx context.Context, item string) (*test.Stock, error)
+	ListStock(ctx context.Context, opts StockListOptions) ([]*test.Stock, string, error)
+	// Restock добавляет quantity к доступному остатку; первое пополнение заводит позицию.
+	Restock(ctx context.Context, item string, quantity int64) (*test.Stock, error)
+	// AdjustStock меняет доступный остаток на delta. Уйти ниже нуля нельзя - codes.FailedPrecondition.
+	AdjustStock(ctx context.Context, item string, delta int64) (*test.Stock, error)
+}
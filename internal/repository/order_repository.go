@@ -3,12 +3,114 @@ package repository
 import (
 	"context"
 	"rpc/pkg/api/test"
+	"time"
 )
 
+// ListOptions описывает страницу, которую нужно вернуть из List.
+// PageSize уже нормализован вызывающей стороной и всегда больше нуля,
+// PageToken - непрозрачный курсор, полученный из предыдущего вызова.
+// Filter и OrderBy - выражения AIP-160/AIP-132, см. пакет filter.
+// ShowDeleted включает в выдачу мягко удалённые заказы.
+// Непустой CustomerID ограничивает выдачу заказами одного покупателя.
+// LabelSelector - селектор меток, см. пакет selector.
+type ListOptions struct {
+	PageSize      int32
+	PageToken     string
+	Filter        string
+	OrderBy       string
+	ShowDeleted   bool
+	CustomerID    string
+	LabelSelector string
+}
+
+// SearchOptions описывает страницу результатов Search. Query - текст поиска как есть,
+// PageSize нормализован так же, как в ListOptions.
+type SearchOptions struct {
+	Query       string
+	PageSize    int32
+	PageToken   string
+	ShowDeleted bool
+}
+
+// StatsOptions задаёт окно [From, To) по времени создания заказа и сколько
+// самых заказываемых позиций вернуть (ItemLimit > 0).
+type StatsOptions struct {
+	From      time.Time
+	To        time.Time
+	ItemLimit int
+}
+
+// HistoryOptions описывает страницу истории одного заказа.
+type HistoryOptions struct {
+	OrderID   string
+	PageSize  int32
+	PageToken string
+}
+
+// IdempotencyKey привязывает запрос на создание заказа к ключу клиента.
+// RequestHash - отпечаток тела запроса, TTL - сколько ключ нельзя занять заново.
+type IdempotencyKey struct {
+	Key         string
+	RequestHash string
+	TTL         time.Duration
+}
+
+// Все изменения заказов в OrderRepository записываются в историю (см. History)
+// от имени автора из контекста (WithActor).
 type OrderRepository interface {
 	Create(ctx context.Context, order *test.Order) error
+	// CreateWithKey создаёт заказ, если ключ ещё не использован, и возвращает его id.
+	// Для уже использованного ключа заказ не создаётся, а возвращается id заказа,
+	// созданного с этим ключом раньше; ключ с другим RequestHash - codes.InvalidArgument.
+	CreateWithKey(ctx context.Context, order *test.Order, key IdempotencyKey) (string, error)
 	Get(ctx context.Context, id string) (*test.Order, error)
-	Update(ctx context.Context, order *test.Order) error
-	Delete(ctx context.Context, id string) error
-	List(ctx context.Context) ([]*test.Order, error)
+	// Update записывает только перечисленные поля заказа (item, quantity, line_items, labels).
+	// Если order.Version > 0, запись проходит только при совпадении версии, иначе codes.Aborted.
+	// При успехе order.Version получает новую версию.
+	Update(ctx context.Context, order *test.Order, fields []string) error
+	// Delete мягко удаляет заказ; version > 0 включает ту же проверку версии, что и в Update.
+	// Резерв удалённого заказа возвращается в остаток.
+	// Удалённые заказы не видны в Get, Update и SetStatus.
+	Delete(ctx context.Context, id string, version int64) error
+	// Undelete восстанавливает мягко удалённый заказ. Открытый заказ резервирует остаток
+	// заново; если его уже не хватает - codes.FailedPrecondition.
+	Undelete(ctx context.Context, id string) error
+	List(ctx context.Context, opts ListOptions) ([]*test.Order, string, error)
+	// Search ищет заказы по названиям позиций: каждое слово Query должно быть началом
+	// слова в названии. Лучшие совпадения идут первыми; пустой запрос - codes.InvalidArgument.
+	Search(ctx context.Context, opts SearchOptions) ([]*test.Order, string, error)
+	// Stream отдаёт в send все заказы, подходящие под Filter/OrderBy/ShowDeleted, не собирая
+	// их в память; ошибка из send прерывает выгрузку и возвращается как есть.
+	Stream(ctx context.Context, opts ListOptions, send func(*test.Order) error) error
+	// History отдаёт записи аудита заказа (order_events) от старых к новым; удалённые
+	// заказы тоже, пока их не вычистили. Неизвестный заказ - codes.NotFound.
+	History(ctx context.Context, opts HistoryOptions) ([]*test.OrderHistoryEntry, string, error)
+	// Stats считает статистику по неудалённым заказам из окна opts.
+	Stats(ctx context.Context, opts StatsOptions) (*test.OrderStats, error)
+	// Пакетные операции выполняются целиком или не выполняются вовсе.
+	// BatchGet возвращает заказы в порядке ids; если каких-то нет, BatchGet и BatchDelete
+	// возвращают OrdersNotFound со всеми отсутствующими id.
+	BatchCreate(ctx context.Context, orders []*test.Order) error
+	BatchGet(ctx context.Context, ids []string) ([]*test.Order, error)
+	BatchDelete(ctx context.Context, ids []string) error
+	// Import быстро записывает уже проверенные заказы одной транзакцией, в обход кэша.
+	Import(ctx context.Context, orders []*test.Order) error
+	// SetStatus переводит заказ из order.Status в to, только если статус (и версия,
+	// если order.Version > 0) не поменялись. При успехе обновляет order.
+	SetStatus(ctx context.Context, order *test.Order, to test.OrderStatus) error
+}
+
+// OrderPurger окончательно удаляет мягко удалённые заказы, просроченные ключи идемпотентности
+// и старые записи журнала изменений.
+type OrderPurger interface {
+	PurgeDeleted(ctx context.Context, olderThan time.Duration, limit int) (int64, error)
+	PurgeIdempotencyKeys(ctx context.Context, limit int) (int64, error)
+	PurgeChanges(ctx context.Context, olderThan time.Duration, limit int) (int64, error)
+}
+
+// OrderExpirer отменяет заказы, не подтверждённые до expire_time.
+type OrderExpirer interface {
+	// ExpirePending отменяет до limit просроченных заказов в PENDING и возвращает
+	// их новое состояние.
+	ExpirePending(ctx context.Context, limit int) ([]*test.Order, error)
 }
@@ -0,0 +1,216 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"rpc/internal/repository"
+	"rpc/pkg/api/test"
+)
+
+// BatchCreate вставляет все заказы и их позиции многострочными INSERT в одной транзакции.
+func (r *orderRepository) BatchCreate(ctx context.Context, orders []*test.Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	insert := r.builder.Insert("orders").
+		Columns("id", "item", "quantity", "status", "version", "customer_id", "total_minor", "currency", "labels", "expire_at").
+		Suffix("RETURNING id, created_at, updated_at")
+	byID := make(map[string]*test.Order, len(orders))
+	for _, order := range orders {
+		totalMinor, totalCurrency, err := moneyToDB(order.Total)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "order %s has invalid total: %v", order.Id, err)
+		}
+		insert = insert.Values(order.Id, order.Item, order.Quantity, statusToDB(order.Status), order.Version, order.CustomerId, totalMinor, totalCurrency, labelsToDB(order.Labels), expireToDB(order.ExpireTime))
+		byID[order.Id] = order
+	}
+
+	query, args, err := insert.ToSql()
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return orderExists(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		var createdAt, updatedAt time.Time
+		if err := rows.Scan(&id, &createdAt, &updatedAt); err != nil {
+			return fmt.Errorf("scanning order: %w", err)
+		}
+		setTimes(byID[id], createdAt, updatedAt)
+	}
+
+	if err := rows.Err(); err != nil {
+		return orderExists(err)
+	}
+	rows.Close()
+
+	if err := r.insertLineItems(ctx, tx, orders); err != nil {
+		return err
+	}
+
+	if err := r.reserveStock(ctx, tx, orders...); err != nil {
+		return err
+	}
+
+	if err := r.recordEvents(ctx, tx, created(orders...)); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// BatchGet возвращает заказы в порядке ids. Если хоть одного нет, возвращается
+// repository.OrdersNotFound со всеми отсутствующими id.
+func (r *orderRepository) BatchGet(ctx context.Context, ids []string) ([]*test.Order, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	query, args, err := r.builder.Select("id", "item", "quantity", "status", "version", "created_at", "updated_at", "customer_id", "total_minor", "currency", "labels", "expire_at").
+		From("orders").
+		Where(squirrel.Eq{"id": ids, "deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	found := make(map[string]*test.Order, len(ids))
+	var orders []*test.Order
+	for rows.Next() {
+		var order test.Order
+		var orderStatus string
+		var createdAt, updatedAt time.Time
+		var totalMinor *int64
+		var totalCurrency *string
+		var expireAt *time.Time
+		if err := rows.Scan(&order.Id, &order.Item, &order.Quantity, &orderStatus, &order.Version, &createdAt, &updatedAt, &order.CustomerId, &totalMinor, &totalCurrency, &order.Labels, &expireAt); err != nil {
+			return nil, fmt.Errorf("scanning order: %w", err)
+		}
+		order.Status = statusFromDB(orderStatus)
+		order.Total = moneyFromDB(totalMinor, totalCurrency)
+		order.Labels = labelsFromDB(order.Labels)
+		order.ExpireTime = expireFromDB(expireAt)
+		setTimes(&order, createdAt, updatedAt)
+		found[order.Id] = &order
+		orders = append(orders, &order)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating rows: %w", err)
+	}
+	rows.Close()
+
+	if missing := missingIDs(ids, found); len(missing) > 0 {
+		return nil, repository.OrdersNotFound(missing)
+	}
+
+	if err := r.attachLineItems(ctx, r.db, orders); err != nil {
+		return nil, err
+	}
+
+	result := make([]*test.Order, len(ids))
+	for i, id := range ids {
+		result[i] = found[id]
+	}
+
+	return result, nil
+}
+
+// BatchDelete мягко удаляет все заказы или ни одного.
+func (r *orderRepository) BatchDelete(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query, args, err := r.builder.Update("orders").
+		Set("deleted_at", squirrel.Expr("NOW()")).
+		Set("version", squirrel.Expr("version + 1")).
+		Where(squirrel.Eq{"id": ids, "deleted_at": nil}).
+		Suffix("RETURNING id").
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	before, err := r.snapshot(ctx, tx, ids...)
+	if err != nil {
+		return err
+	}
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	deleted := make(map[string]*test.Order, len(ids))
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return fmt.Errorf("scanning id: %w", err)
+		}
+		deleted[id] = nil
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterating rows: %w", err)
+	}
+	rows.Close()
+
+	// транзакция откатится в defer, ни один заказ не останется удалённым
+	if missing := missingIDs(ids, deleted); len(missing) > 0 {
+		return repository.OrdersNotFound(missing)
+	}
+
+	if err := r.releaseStock(ctx, tx, ids, true); err != nil {
+		return err
+	}
+
+	if err := r.recordChange(ctx, tx, test.OrderHistoryAction_ORDER_HISTORY_ACTION_DELETED, before, ids...); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func missingIDs(ids []string, found map[string]*test.Order) []string {
+	var missing []string
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		if _, ok := found[id]; !ok && !seen[id] {
+			seen[id] = true
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
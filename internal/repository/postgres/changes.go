@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"rpc/internal/repository"
+)
+
+// changesChannel - канал NOTIFY, в который пишут триггеры orders_notify_*.
+const changesChannel = "order_changes"
+
+type changeLog struct {
+	db      *pgxpool.Pool
+	builder squirrel.StatementBuilderType
+}
+
+func NewChangeLog(db *pgxpool.Pool) repository.ChangeLog {
+	return &changeLog{
+		db:      db,
+		builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// stableChanges отсекает записи, до которых ещё могут зафиксироваться транзакции
+// с меньшим txid: все транзакции ниже xmin снимка уже завершены.
+const stableChanges = "txid < pg_snapshot_xmin(pg_current_snapshot())::text::bigint"
+
+func (c *changeLog) After(ctx context.Context, pos repository.Position, limit int) ([]repository.Change, error) {
+	query, args, err := c.builder.Select("txid", "seq", "order_id", "change_type", "changed_at").
+		From("order_changes").
+		Where(squirrel.Expr("(txid, seq) > (?, ?)", pos.TxID, pos.Seq)).
+		Where(stableChanges).
+		OrderBy("txid", "seq").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := c.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var changes []repository.Change
+	for rows.Next() {
+		var change repository.Change
+		if err := rows.Scan(&change.Position.TxID, &change.Position.Seq, &change.OrderID, &change.Type, &change.Time); err != nil {
+			return nil, fmt.Errorf("scanning change: %w", err)
+		}
+		changes = append(changes, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating changes: %w", err)
+	}
+
+	return changes, nil
+}
+
+func (c *changeLog) Purged(ctx context.Context) (repository.Position, error) {
+	var pos repository.Position
+	err := c.db.QueryRow(ctx, "SELECT txid, seq FROM order_changes_purged").Scan(&pos.TxID, &pos.Seq)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return repository.Position{}, nil
+	}
+	return pos, err
+}
+
+func (c *changeLog) Latest(ctx context.Context) (repository.Position, error) {
+	query, args, err := c.builder.Select("txid", "seq").
+		From("order_changes").
+		Where(stableChanges).
+		OrderBy("txid DESC", "seq DESC").
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return repository.Position{}, err
+	}
+
+	var pos repository.Position
+	err = c.db.QueryRow(ctx, query, args...).Scan(&pos.TxID, &pos.Seq)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// записи могли уже вычистить
+		return c.Purged(ctx)
+	}
+	return pos, err
+}
+
+// Listen держит отдельное соединение из пула на LISTEN, пока не отменят ctx.
+func (c *changeLog) Listen(ctx context.Context, ready func(), wake func()) error {
+	conn, err := c.db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// соединение после LISTEN нельзя возвращать в пул как есть
+	defer func() {
+		conn.Conn().Close(context.Background())
+		conn.Release()
+	}()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+changesChannel); err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+	ready()
+
+	for {
+		// уведомление пустое: изменения читаются из журнала по позиции
+		if _, err := conn.Conn().WaitForNotification(ctx); err != nil {
+			return err
+		}
+		wake()
+	}
+}
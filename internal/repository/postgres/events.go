@@ -0,0 +1,258 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"rpc/internal/repository"
+	"rpc/pkg/api/test"
+)
+
+// В базе действие хранится без префикса enum: ORDER_HISTORY_ACTION_CREATED -> CREATED.
+const actionPrefix = "ORDER_HISTORY_ACTION_"
+
+// orderEvent - одна запись order_events; old пуст для созданных заказов, new - для очищенных.
+type orderEvent struct {
+	action test.OrderHistoryAction
+	old    *test.Order
+	new    *test.Order
+}
+
+var unmarshalEvent = protojson.UnmarshalOptions{DiscardUnknown: true}
+
+// snapshot читает заказы с позициями (удалённые тоже) и блокирует их строки до конца
+// транзакции, чтобы в историю попало именно то состояние, которое перезаписывается.
+// Заказов, которых нет, в результате нет.
+func (r *orderRepository) snapshot(ctx context.Context, tx pgx.Tx, ids ...string) (map[string]*test.Order, error) {
+	query, args, err := r.builder.Select(listColumns...).
+		From("orders").
+		Where(squirrel.Eq{"id": ids}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	found := make(map[string]*test.Order, len(ids))
+	var orders []*test.Order
+	for rows.Next() {
+		row, err := scanListRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		found[row.order.Id] = row.order
+		orders = append(orders, row.order)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating rows: %w", err)
+	}
+	rows.Close()
+
+	if err := r.attachLineItems(ctx, tx, orders); err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// changed собирает события по снимкам до и после изменения в порядке ids.
+func changed(action test.OrderHistoryAction, ids []string, before, after map[string]*test.Order) []orderEvent {
+	events := make([]orderEvent, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if after[id] == nil || seen[id] {
+			continue
+		}
+		seen[id] = true
+		events = append(events, orderEvent{action: action, old: before[id], new: after[id]})
+	}
+	return events
+}
+
+// recordChange снимает заказы после изменения и пишет события с состоянием before.
+func (r *orderRepository) recordChange(ctx context.Context, tx pgx.Tx, action test.OrderHistoryAction, before map[string]*test.Order, ids ...string) error {
+	after, err := r.snapshot(ctx, tx, ids...)
+	if err != nil {
+		return err
+	}
+	return r.recordEvents(ctx, tx, changed(action, ids, before, after))
+}
+
+func created(orders ...*test.Order) []orderEvent {
+	events := make([]orderEvent, len(orders))
+	for i, order := range orders {
+		events[i] = orderEvent{action: test.OrderHistoryAction_ORDER_HISTORY_ACTION_CREATED, new: order}
+	}
+	return events
+}
+
+func purged(ids []string, before map[string]*test.Order) []orderEvent {
+	events := make([]orderEvent, 0, len(ids))
+	for _, id := range ids {
+		if order := before[id]; order != nil {
+			events = append(events, orderEvent{action: test.OrderHistoryAction_ORDER_HISTORY_ACTION_PURGED, old: order})
+		}
+	}
+	return events
+}
+
+// id - заказ, к которому относится событие.
+func (e orderEvent) id() string {
+	if e.new != nil {
+		return e.new.Id
+	}
+	return e.old.Id
+}
+
+func eventValue(order *test.Order) (any, error) {
+	if order == nil {
+		return nil, nil
+	}
+	data, err := protojson.Marshal(order)
+	if err != nil {
+		return nil, fmt.Errorf("marshal order %s: %w", order.Id, err)
+	}
+	return string(data), nil
+}
+
+// recordEvents пишет события в order_events в транзакции самого изменения,
+// от имени автора из контекста.
+func (r *orderRepository) recordEvents(ctx context.Context, q querier, events []orderEvent) error {
+	actor := repository.ActorFrom(ctx)
+
+	for len(events) > 0 {
+		n := min(len(events), maxInsertRows)
+
+		insert := r.builder.Insert("order_events").
+			Columns("order_id", "action", "actor", "old_value", "new_value")
+		for _, event := range events[:n] {
+			oldValue, err := eventValue(event.old)
+			if err != nil {
+				return err
+			}
+			newValue, err := eventValue(event.new)
+			if err != nil {
+				return err
+			}
+			insert = insert.Values(event.id(), strings.TrimPrefix(event.action.String(), actionPrefix), actor, oldValue, newValue)
+		}
+		events = events[n:]
+
+		query, args, err := insert.ToSql()
+		if err != nil {
+			return err
+		}
+		if _, err := q.Exec(ctx, query, args...); err != nil {
+			return fmt.Errorf("insert order events: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *orderRepository) History(ctx context.Context, opts repository.HistoryOptions) ([]*test.OrderHistoryEntry, string, error) {
+	builder := r.builder.Select("id", "action", "actor", "old_value", "new_value", "created_at").
+		From("order_events").
+		Where(squirrel.Eq{"order_id": opts.OrderID}).
+		OrderBy("id").
+		Limit(uint64(opts.PageSize) + 1)
+
+	if opts.PageToken != "" {
+		var cursor historyCursor
+		err := decodePageToken(opts.PageToken, historyToken, &cursor)
+		if err != nil || cursor.OrderID != opts.OrderID {
+			return nil, "", status.Error(codes.InvalidArgument, "invalid page token")
+		}
+		builder = builder.Where(squirrel.Gt{"id": cursor.EventID})
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, "", err
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	var entries []*test.OrderHistoryEntry
+	var lastID int64
+	var next string
+	for rows.Next() {
+		var id int64
+		var action string
+		var oldValue, newValue []byte
+		var changedAt time.Time
+		entry := &test.OrderHistoryEntry{}
+		if err := rows.Scan(&id, &action, &entry.Actor, &oldValue, &newValue, &changedAt); err != nil {
+			return nil, "", fmt.Errorf("scanning order event: %w", err)
+		}
+		if len(entries) == int(opts.PageSize) {
+			next = encodePageToken(historyToken, historyCursor{OrderID: opts.OrderID, EventID: lastID})
+			break
+		}
+
+		entry.Action = test.OrderHistoryAction(test.OrderHistoryAction_value[actionPrefix+action])
+		entry.ChangeTime = timestamppb.New(changedAt)
+		if entry.OldOrder, err = eventOrder(oldValue); err != nil {
+			return nil, "", err
+		}
+		if entry.NewOrder, err = eventOrder(newValue); err != nil {
+			return nil, "", err
+		}
+		entries = append(entries, entry)
+		lastID = id
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("iterating order events: %w", err)
+	}
+	rows.Close()
+
+	if len(entries) == 0 && opts.PageToken == "" {
+		// пустая история бывает и у существующего заказа, созданного до появления order_events
+		query, args, err := r.builder.Select("1").
+			Prefix("SELECT EXISTS (").
+			From("orders").
+			Where(squirrel.Eq{"id": opts.OrderID}).
+			Suffix(")").
+			ToSql()
+		if err != nil {
+			return nil, "", err
+		}
+		var exists bool
+		if err := r.db.QueryRow(ctx, query, args...).Scan(&exists); err != nil {
+			return nil, "", err
+		}
+		if !exists {
+			return nil, "", repository.OrderNotFound(opts.OrderID)
+		}
+	}
+
+	return entries, next, nil
+}
+
+func eventOrder(data []byte) (*test.Order, error) {
+	if data == nil {
+		return nil, nil
+	}
+	var order test.Order
+	if err := unmarshalEvent.Unmarshal(data, &order); err != nil {
+		return nil, fmt.Errorf("unmarshal order event: %w", err)
+	}
+	return &order, nil
+}
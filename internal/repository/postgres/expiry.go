@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"rpc/internal/repository"
+	"rpc/pkg/api/test"
+)
+
+func NewOrderExpirer(db *pgxpool.Pool) repository.OrderExpirer {
+	return &orderRepository{
+		db:      db,
+		builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// expireToDB - значение для колонки expire_at; nil - заказ не истекает.
+func expireToDB(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+func expireFromDB(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+// ExpirePending отменяет пачку просроченных заказов одной транзакцией: резервы
+// возвращаются в остаток, изменения пишутся в историю. Строки, которые сейчас держит
+// другая транзакция, пропускаются (SKIP LOCKED) - их заберёт следующий проход,
+// если заказ к тому времени всё ещё в PENDING.
+func (r *orderRepository) ExpirePending(ctx context.Context, limit int) ([]*test.Order, error) {
+	// условие повторяет предикат idx_orders_expire_at литералом: с параметром вместо
+	// 'PENDING' обобщённый план подготовленного запроса не смог бы взять частичный индекс
+	query, args, err := r.builder.Select("id").
+		From("orders").
+		Where("status = 'PENDING' AND deleted_at IS NULL").
+		Where(squirrel.LtOrEq{"expire_at": time.Now().UTC()}).
+		OrderBy("expire_at").
+		Limit(uint64(limit)).
+		Suffix("FOR UPDATE SKIP LOCKED").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scanning order id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating rows: %w", err)
+	}
+	rows.Close()
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	before, err := r.snapshot(ctx, tx, ids...)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err = r.builder.Update("orders").
+		Set("status", statusToDB(test.OrderStatus_ORDER_STATUS_CANCELLED)).
+		Set("version", squirrel.Expr("version + 1")).
+		Set("expire_at", nil).
+		Where(squirrel.Eq{"id": ids}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return nil, err
+	}
+
+	if err := r.releaseStock(ctx, tx, ids, true); err != nil {
+		return nil, err
+	}
+
+	after, err := r.snapshot(ctx, tx, ids...)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.recordEvents(ctx, tx, changed(test.OrderHistoryAction_ORDER_HISTORY_ACTION_STATUS_CHANGED, ids, before, after)); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	expired := make([]*test.Order, 0, len(ids))
+	for _, id := range ids {
+		expired = append(expired, after[id])
+	}
+	return expired, nil
+}
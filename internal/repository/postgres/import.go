@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"rpc/pkg/api/test"
+)
+
+// Import пишет заказы и их позиции через COPY в одной транзакции:
+// на сотнях тысяч записей это на порядок быстрее многострочных INSERT.
+func (r *orderRepository) Import(ctx context.Context, orders []*test.Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	lines, err := lineItemRows(orders)
+	if err != nil {
+		return err
+	}
+
+	totals := make([][2]any, len(orders))
+	for i, order := range orders {
+		totalMinor, totalCurrency, err := moneyToDB(order.Total)
+		if err != nil {
+			return fmt.Errorf("order %s has invalid total: %w", order.Id, err)
+		}
+		totals[i] = [2]any{totalMinor, totalCurrency}
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.CopyFrom(ctx,
+		pgx.Identifier{"orders"},
+		[]string{"id", "item", "quantity", "status", "version", "customer_id", "total_minor", "currency", "labels", "expire_at"},
+		pgx.CopyFromSlice(len(orders), func(i int) ([]any, error) {
+			order := orders[i]
+			return []any{order.Id, order.Item, order.Quantity, statusToDB(order.Status), order.Version, order.CustomerId, totals[i][0], totals[i][1], labelsToDB(order.Labels), expireToDB(order.ExpireTime)}, nil
+		}),
+	)
+	if err != nil {
+		return orderExists(fmt.Errorf("copy orders: %w", err))
+	}
+
+	_, err = tx.CopyFrom(ctx,
+		pgx.Identifier{"order_items"},
+		[]string{"order_id", "line_no", "item", "quantity", "unit_price_minor", "currency"},
+		pgx.CopyFromRows(lines),
+	)
+	if err != nil {
+		return fmt.Errorf("copy line items: %w", err)
+	}
+
+	// открытые заказы держат остаток так же, как созданные через CreateOrder;
+	// отгруженные и отменённые приходят уже без резерва
+	var open []*test.Order
+	for _, order := range orders {
+		if holdsStock(order.Status) {
+			open = append(open, order)
+		}
+	}
+	if err := r.reserveStock(ctx, tx, open...); err != nil {
+		return err
+	}
+
+	if err := r.recordEvents(ctx, tx, created(orders...)); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
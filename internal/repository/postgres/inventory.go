@@ -0,0 +1,159 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"rpc/internal/repository"
+	"rpc/pkg/api/test"
+)
+
+type inventoryRepository struct {
+	db      *pgxpool.Pool
+	builder squirrel.StatementBuilderType
+}
+
+func NewInventoryRepository(db *pgxpool.Pool) repository.InventoryRepository {
+	return &inventoryRepository{
+		db:      db,
+		builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// stockColumns - колонки для scanStock; reserved считается по резервам открытых заказов.
+var stockColumns = []string{
+	"i.item",
+	"i.available",
+	"COALESCE((SELECT sum(r.quantity)::bigint FROM inventory_reservations r WHERE r.item = i.item), 0)",
+	"i.updated_at",
+}
+
+func scanStock(row pgx.Row) (*test.Stock, error) {
+	var stock test.Stock
+	var updatedAt time.Time
+	if err := row.Scan(&stock.Item, &stock.Available, &stock.Reserved, &updatedAt); err != nil {
+		return nil, err
+	}
+	stock.UpdateTime = timestamppb.New(updatedAt)
+	return &stock, nil
+}
+
+func (r *inventoryRepository) GetStock(ctx context.Context, item string) (*test.Stock, error) {
+	query, args, err := r.builder.Select(stockColumns...).
+		From("inventory i").
+		Where(squirrel.Eq{"i.item": item}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	stock, err := scanStock(r.db.QueryRow(ctx, query, args...))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, repository.StockNotFound(item)
+		}
+		return nil, fmt.Errorf("scanning stock: %w", err)
+	}
+	return stock, nil
+}
+
+func (r *inventoryRepository) ListStock(ctx context.Context, opts repository.StockListOptions) ([]*test.Stock, string, error) {
+	builder := r.builder.Select(stockColumns...).
+		From("inventory i").
+		OrderBy("i.item").
+		Limit(uint64(opts.PageSize) + 1)
+
+	if opts.PageToken != "" {
+		var cursor stockCursor
+		if err := decodePageToken(opts.PageToken, stockToken, &cursor); err != nil || cursor.Item == "" {
+			return nil, "", status.Error(codes.InvalidArgument, "invalid page token")
+		}
+		builder = builder.Where(squirrel.Gt{"i.item": cursor.Item})
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, "", err
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	var stocks []*test.Stock
+	var next string
+	for rows.Next() {
+		stock, err := scanStock(rows)
+		if err != nil {
+			return nil, "", fmt.Errorf("scanning stock: %w", err)
+		}
+		if len(stocks) == int(opts.PageSize) {
+			next = encodePageToken(stockToken, stockCursor{Item: stocks[len(stocks)-1].Item})
+			break
+		}
+		stocks = append(stocks, stock)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("iterating stock: %w", err)
+	}
+
+	return stocks, next, nil
+}
+
+func (r *inventoryRepository) Restock(ctx context.Context, item string, quantity int64) (*test.Stock, error) {
+	query, args, err := r.builder.Insert("inventory AS i").
+		Columns("item", "available").
+		Values(item, quantity).
+		Suffix("ON CONFLICT (item) DO UPDATE SET available = i.available + EXCLUDED.available").
+		Suffix("RETURNING " + strings.Join(stockColumns, ", ")).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	stock, err := scanStock(r.db.QueryRow(ctx, query, args...))
+	if err != nil {
+		return nil, fmt.Errorf("restock: %w", err)
+	}
+	return stock, nil
+}
+
+// AdjustStock меняет остаток и возвращает его тем же запросом, так что ответ не
+// подхватит правки параллельных транзакций.
+func (r *inventoryRepository) AdjustStock(ctx context.Context, item string, delta int64) (*test.Stock, error) {
+	query, args, err := r.builder.Update("inventory i").
+		Set("available", squirrel.Expr("i.available + ?", delta)).
+		Where(squirrel.Eq{"i.item": item}).
+		Where(squirrel.Expr("i.available + ? >= 0", delta)).
+		Suffix("RETURNING " + strings.Join(stockColumns, ", ")).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	stock, err := scanStock(r.db.QueryRow(ctx, query, args...))
+	if err == nil {
+		return stock, nil
+	}
+	if err != pgx.ErrNoRows {
+		return nil, fmt.Errorf("adjust stock: %w", err)
+	}
+
+	// позиции нет или остаток ушёл бы в минус; текущий остаток нужен только для сообщения
+	current, err := r.GetStock(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+	return nil, status.Errorf(codes.FailedPrecondition,
+		"stock of item %q cannot go below zero: available %d, delta %d", item, current.Available, delta)
+}
@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"rpc/internal/selector"
+)
+
+// labelsToDB - значение для колонки labels: NULL в ней запрещён, пустые метки пишутся как {}.
+func labelsToDB(labels map[string]string) map[string]string {
+	if labels == nil {
+		return map[string]string{}
+	}
+	return labels
+}
+
+// labelsFromDB оставляет пустые метки nil, как у заказа, который пришёл без меток.
+func labelsFromDB(labels map[string]string) map[string]string {
+	if len(labels) == 0 {
+		return nil
+	}
+	return labels
+}
+
+// labelPair - условие labels @> {"key": "value"}; оно и labels ? key покрываются GIN-индексом.
+func labelPair(key, value string) squirrel.Sqlizer {
+	data, _ := json.Marshal(map[string]string{key: value})
+	return squirrel.Expr("labels @> ?::jsonb", string(data))
+}
+
+// labelPredicate переводит селектор меток в условие WHERE; nil - селектор пуст.
+func labelPredicate(s string) (squirrel.Sqlizer, error) {
+	reqs, err := selector.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(reqs) == 0 {
+		return nil, nil
+	}
+
+	and := squirrel.And{}
+	for _, req := range reqs {
+		switch req.Op {
+		case selector.OpEquals:
+			and = append(and, labelPair(req.Key, req.Values[0]))
+		case selector.OpNotEquals:
+			and = append(and, squirrel.Expr("NOT (?)", labelPair(req.Key, req.Values[0])))
+		case selector.OpIn, selector.OpNotIn:
+			or := squirrel.Or{}
+			for _, v := range req.Values {
+				or = append(or, labelPair(req.Key, v))
+			}
+			if req.Op == selector.OpNotIn {
+				and = append(and, squirrel.Expr("NOT (?)", or))
+			} else {
+				and = append(and, or)
+			}
+		case selector.OpExists:
+			// ?? - экранированный для squirrel оператор jsonb ?
+			and = append(and, squirrel.Expr("labels ?? ?", req.Key))
+		case selector.OpDoesNotExist:
+			and = append(and, squirrel.Expr("NOT (labels ?? ?)", req.Key))
+		default:
+			return nil, fmt.Errorf("unsupported operator %q", req.Op)
+		}
+	}
+	return and, nil
+}
@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"rpc/internal/repository"
+)
+
+func TestLabelPredicate(t *testing.T) {
+	tests := []struct {
+		selector string
+		wantSQL  string
+		wantArgs []any
+	}{
+		{selector: ""},
+		{selector: "channel=web", wantSQL: "(labels @> $1::jsonb)", wantArgs: []any{`{"channel":"web"}`}},
+		{selector: "channel!=web", wantSQL: "(NOT (labels @> $1::jsonb))", wantArgs: []any{`{"channel":"web"}`}},
+		{
+			selector: "region in (eu,us)",
+			wantSQL:  "((labels @> $1::jsonb OR labels @> $2::jsonb))",
+			wantArgs: []any{`{"region":"eu"}`, `{"region":"us"}`},
+		},
+		{
+			selector: "region notin (eu)",
+			wantSQL:  "(NOT ((labels @> $1::jsonb)))",
+			wantArgs: []any{`{"region":"eu"}`},
+		},
+		{selector: "vip", wantSQL: "(labels ? $1)", wantArgs: []any{"vip"}},
+		{selector: "!vip", wantSQL: "(NOT (labels ? $1))", wantArgs: []any{"vip"}},
+		{
+			selector: `channel=web,!vip`,
+			wantSQL:  "(labels @> $1::jsonb AND NOT (labels ? $2))",
+			wantArgs: []any{`{"channel":"web"}`, "vip"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			pred, err := labelPredicate(tt.selector)
+			if err != nil {
+				t.Fatalf("labelPredicate(%q) error: %v", tt.selector, err)
+			}
+			if pred == nil {
+				if tt.wantSQL != "" {
+					t.Fatalf("labelPredicate(%q) = nil, want %q", tt.selector, tt.wantSQL)
+				}
+				return
+			}
+
+			// через builder, чтобы проверить и экранирование ?? в плейсхолдерах
+			sql, args, err := testRepository().builder.Select("id").From("orders").Where(pred).ToSql()
+			if err != nil {
+				t.Fatalf("ToSql() error: %v", err)
+			}
+			sql = strings.TrimPrefix(sql, "SELECT id FROM orders WHERE ")
+			if sql != tt.wantSQL || !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("labelPredicate(%q) = %q %v, want %q %v", tt.selector, sql, args, tt.wantSQL, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestListQueryLabelSelector(t *testing.T) {
+	_, _, err := testRepository().listQuery(repository.ListOptions{PageSize: 10, LabelSelector: "region in eu"})
+	if err == nil || !strings.Contains(err.Error(), "invalid label_selector: ") {
+		t.Errorf("listQuery() error = %v, want invalid label_selector", err)
+	}
+
+	token := encodePageToken(listToken, listCursor{Labels: "channel=web", Values: []string{"2024-01-01T00:00:00Z", "order-1"}})
+	_, _, err = testRepository().listQuery(repository.ListOptions{PageSize: 10, PageToken: token, LabelSelector: "channel=app"})
+	if err == nil || err.Error() != "invalid page token" {
+		t.Errorf("listQuery() with a token for another selector error = %v, want invalid page token", err)
+	}
+}
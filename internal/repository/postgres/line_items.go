@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"rpc/pkg/api/test"
+)
+
+const maxInsertRows = 1000
+
+// querier - общее у *pgxpool.Pool и pgx.Tx, чтобы хелперы работали и в транзакции, и без неё.
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// replaceLineItems перезаписывает позиции заказа одним DELETE и одним многострочным INSERT.
+func (r *orderRepository) replaceLineItems(ctx context.Context, q querier, order *test.Order) error {
+	query, args, err := r.builder.Delete("order_items").
+		Where(squirrel.Eq{"order_id": order.Id}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	if _, err := q.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("delete line items: %w", err)
+	}
+
+	return r.insertLineItems(ctx, q, []*test.Order{order})
+}
+
+// insertLineItems вставляет позиции сразу нескольких заказов многострочными INSERT,
+// не больше maxInsertRows строк в каждом, чтобы не упереться в лимит параметров.
+func (r *orderRepository) insertLineItems(ctx context.Context, q querier, orders []*test.Order) error {
+	rows, err := lineItemRows(orders)
+	if err != nil {
+		return err
+	}
+
+	for len(rows) > 0 {
+		n := min(len(rows), maxInsertRows)
+
+		insert := r.builder.Insert("order_items").
+			Columns("order_id", "line_no", "item", "quantity", "unit_price_minor", "currency")
+		for _, row := range rows[:n] {
+			insert = insert.Values(row...)
+		}
+		rows = rows[n:]
+
+		query, args, err := insert.ToSql()
+		if err != nil {
+			return err
+		}
+		if _, err := q.Exec(ctx, query, args...); err != nil {
+			return fmt.Errorf("insert line items: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// lineItemRows раскладывает позиции заказов в строки order_items.
+func lineItemRows(orders []*test.Order) ([][]any, error) {
+	var rows [][]any
+	for _, order := range orders {
+		for i, line := range order.LineItems {
+			priceMinor, priceCurrency, err := moneyToDB(line.UnitPrice)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "order %s line %d has invalid unit_price: %v", order.Id, i+1, err)
+			}
+			rows = append(rows, []any{order.Id, i + 1, line.Item, line.Quantity, priceMinor, priceCurrency})
+		}
+	}
+	return rows, nil
+}
+
+// loadLineItems достаёт позиции сразу для нескольких заказов одним запросом.
+func (r *orderRepository) loadLineItems(ctx context.Context, q querier, ids []string) (map[string][]*test.LineItem, error) {
+	lines := make(map[string][]*test.LineItem, len(ids))
+	if len(ids) == 0 {
+		return lines, nil
+	}
+
+	query, args, err := r.builder.Select("order_id", "item", "quantity", "unit_price_minor", "currency").
+		From("order_items").
+		Where(squirrel.Eq{"order_id": ids}).
+		OrderBy("order_id", "line_no").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var orderID string
+		var line test.LineItem
+		var priceMinor *int64
+		var priceCurrency *string
+		if err := rows.Scan(&orderID, &line.Item, &line.Quantity, &priceMinor, &priceCurrency); err != nil {
+			return nil, fmt.Errorf("scanning line item: %w", err)
+		}
+		line.UnitPrice = moneyFromDB(priceMinor, priceCurrency)
+		lines[orderID] = append(lines[orderID], &line)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating line items: %w", err)
+	}
+
+	return lines, nil
+}
+
+func (r *orderRepository) attachLineItems(ctx context.Context, q querier, orders []*test.Order) error {
+	ids := make([]string, len(orders))
+	for i, order := range orders {
+		ids[i] = order.Id
+	}
+
+	lines, err := r.loadLineItems(ctx, q, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, order := range orders {
+		order.LineItems = lines[order.Id]
+	}
+
+	return nil
+}
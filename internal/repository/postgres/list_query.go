@@ -0,0 +1,345 @@
+package postgres
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"rpc/internal/filter"
+	"rpc/internal/repository"
+	"rpc/pkg/api/test"
+)
+
+type fieldKind int
+
+const (
+	stringField fieldKind = iota
+	intField
+	timeField
+	statusField
+)
+
+type listField struct {
+	column string
+	kind   fieldKind
+}
+
+// listFields - поля, доступные в filter и order_by, и колонки, в которые они транслируются.
+// Всё, чего здесь нет, отклоняется как неизвестное поле.
+var listFields = map[string]listField{
+	"id":          {column: "id", kind: stringField},
+	"item":        {column: "item", kind: stringField},
+	"quantity":    {column: "quantity", kind: intField},
+	"status":      {column: "status", kind: statusField},
+	"create_time": {column: "created_at", kind: timeField},
+	"update_time": {column: "updated_at", kind: timeField},
+	"customer_id": {column: "customer_id", kind: stringField},
+}
+
+var defaultOrder = []filter.OrderField{{Field: "create_time"}}
+
+// listColumns - колонки orders в порядке, который ожидает scanListRow.
+var listColumns = []string{"id", "item", "quantity", "status", "version", "created_at", "updated_at", "deleted_at", "customer_id", "total_minor", "currency", "labels", "expire_at"}
+
+// listRow - отсканированная строка вместе с колонками, которых нет в test.Order.
+type listRow struct {
+	order     *test.Order
+	status    string
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+func (r listRow) column(name string) any {
+	switch name {
+	case "id":
+		return r.order.Id
+	case "item":
+		return r.order.Item
+	case "quantity":
+		return r.order.Quantity
+	case "status":
+		return r.status
+	case "created_at":
+		return r.createdAt
+	case "updated_at":
+		return r.updatedAt
+	case "customer_id":
+		return r.order.CustomerId
+	}
+	return nil
+}
+
+// scanListRow сканирует колонки listColumns; extra получает колонки, выбранные после них.
+func scanListRow(rows pgx.Rows, extra ...any) (listRow, error) {
+	row := listRow{order: &test.Order{}}
+	var deletedAt *time.Time
+	var totalMinor *int64
+	var totalCurrency *string
+	var expireAt *time.Time
+	dest := []any{&row.order.Id, &row.order.Item, &row.order.Quantity, &row.status, &row.order.Version, &row.createdAt, &row.updatedAt, &deletedAt, &row.order.CustomerId, &totalMinor, &totalCurrency, &row.order.Labels, &expireAt}
+	err := rows.Scan(append(dest, extra...)...)
+	if err != nil {
+		return row, fmt.Errorf("scanning order: %w", err)
+	}
+	row.order.Status = statusFromDB(row.status)
+	row.order.Total = moneyFromDB(totalMinor, totalCurrency)
+	row.order.Labels = labelsFromDB(row.order.Labels)
+	row.order.ExpireTime = expireFromDB(expireAt)
+	setTimes(row.order, row.createdAt, row.updatedAt)
+	if deletedAt != nil {
+		row.order.DeleteTime = timestamppb.New(*deletedAt)
+	}
+	return row, nil
+}
+
+func (f listField) parseValue(name, raw string) (any, error) {
+	switch f.kind {
+	case intField:
+		v, err := strconv.ParseInt(raw, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("field %q expects an integer, got %q", name, raw)
+		}
+		return int32(v), nil
+	case timeField:
+		t, err := time.Parse(time.RFC3339Nano, raw)
+		if err != nil {
+			return nil, fmt.Errorf("field %q expects an RFC 3339 timestamp, got %q", name, raw)
+		}
+		return t.UTC(), nil
+	case statusField:
+		short := strings.TrimPrefix(raw, statusPrefix)
+		if _, ok := test.OrderStatus_value[statusPrefix+short]; !ok || short == "UNSPECIFIED" {
+			return nil, fmt.Errorf("field %q expects an order status, got %q", name, raw)
+		}
+		return short, nil
+	}
+	return raw, nil
+}
+
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case int32:
+		return strconv.FormatInt(int64(v), 10)
+	case string:
+		return v
+	}
+	return fmt.Sprint(v)
+}
+
+type sortKey struct {
+	name  string
+	field listField
+	desc  bool
+}
+
+// listQuery строит SELECT для одной страницы: фильтр, сортировку и keyset-условие
+// из page_token. Ошибки относятся к аргументам запроса.
+func (r *orderRepository) listQuery(opts repository.ListOptions) (squirrel.SelectBuilder, []sortKey, error) {
+	builder := r.builder.Select(listColumns...).
+		From("orders")
+
+	if !opts.ShowDeleted {
+		builder = builder.Where(squirrel.Eq{"deleted_at": nil})
+	}
+	if opts.CustomerID != "" {
+		builder = builder.Where(squirrel.Eq{"customer_id": opts.CustomerID})
+	}
+
+	expr, err := filter.Parse(opts.Filter)
+	if err != nil {
+		return builder, nil, fmt.Errorf("invalid filter: %w", err)
+	}
+	if expr != nil {
+		pred, err := filterPredicate(expr)
+		if err != nil {
+			return builder, nil, fmt.Errorf("invalid filter: %w", err)
+		}
+		builder = builder.Where(pred)
+	}
+
+	labels, err := labelPredicate(opts.LabelSelector)
+	if err != nil {
+		return builder, nil, fmt.Errorf("invalid label_selector: %w", err)
+	}
+	if labels != nil {
+		builder = builder.Where(labels)
+	}
+
+	keys, err := parseSortKeys(opts.OrderBy)
+	if err != nil {
+		return builder, nil, fmt.Errorf("invalid order_by: %w", err)
+	}
+	for _, k := range keys {
+		if k.desc {
+			builder = builder.OrderBy(k.field.column + " DESC")
+		} else {
+			builder = builder.OrderBy(k.field.column)
+		}
+	}
+
+	if opts.PageToken != "" {
+		var cursor listCursor
+		err := decodePageToken(opts.PageToken, listToken, &cursor)
+		if err != nil || cursor.Filter != opts.Filter || cursor.OrderBy != opts.OrderBy || cursor.Customer != opts.CustomerID ||
+			cursor.Labels != opts.LabelSelector || cursor.ShowDeleted != opts.ShowDeleted {
+			return builder, nil, fmt.Errorf("invalid page token")
+		}
+		pred, err := keysetPredicate(keys, cursor.Values)
+		if err != nil {
+			return builder, nil, fmt.Errorf("invalid page token")
+		}
+		builder = builder.Where(pred)
+	}
+
+	return builder, keys, nil
+}
+
+func parseSortKeys(orderBy string) ([]sortKey, error) {
+	fields, err := filter.ParseOrderBy(orderBy)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		fields = defaultOrder
+	}
+
+	var keys []sortKey
+	hasID := false
+	for _, f := range fields {
+		field, ok := listFields[f.Field]
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q", f.Field)
+		}
+		hasID = hasID || field.column == "id"
+		keys = append(keys, sortKey{name: f.Field, field: field, desc: f.Desc})
+	}
+
+	// id замыкает сортировку, чтобы порядок был полным и keyset не терял строки
+	if !hasID {
+		keys = append(keys, sortKey{name: "id", field: listFields["id"]})
+	}
+
+	return keys, nil
+}
+
+// keysetPredicate выбирает строки строго после курсора:
+// (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ... с учётом направления каждого ключа.
+func keysetPredicate(keys []sortKey, raw []string) (squirrel.Sqlizer, error) {
+	if len(raw) != len(keys) {
+		return nil, fmt.Errorf("cursor has %d values, want %d", len(raw), len(keys))
+	}
+
+	values := make([]any, len(keys))
+	for i, k := range keys {
+		v, err := k.field.parseValue(k.name, raw[i])
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+
+	or := squirrel.Or{}
+	for i, k := range keys {
+		and := squirrel.And{}
+		for j := 0; j < i; j++ {
+			and = append(and, squirrel.Eq{keys[j].field.column: values[j]})
+		}
+		if k.desc {
+			and = append(and, squirrel.Lt{k.field.column: values[i]})
+		} else {
+			and = append(and, squirrel.Gt{k.field.column: values[i]})
+		}
+		or = append(or, and)
+	}
+
+	return or, nil
+}
+
+func cursorValues(keys []sortKey, row listRow) []string {
+	values := make([]string, len(keys))
+	for i, k := range keys {
+		values[i] = formatValue(row.column(k.field.column))
+	}
+	return values
+}
+
+func filterPredicate(expr filter.Expr) (squirrel.Sqlizer, error) {
+	switch e := expr.(type) {
+	case filter.And:
+		and := squirrel.And{}
+		for _, sub := range e.Exprs {
+			pred, err := filterPredicate(sub)
+			if err != nil {
+				return nil, err
+			}
+			and = append(and, pred)
+		}
+		return and, nil
+	case filter.Or:
+		or := squirrel.Or{}
+		for _, sub := range e.Exprs {
+			pred, err := filterPredicate(sub)
+			if err != nil {
+				return nil, err
+			}
+			or = append(or, pred)
+		}
+		return or, nil
+	case filter.Not:
+		pred, err := filterPredicate(e.Expr)
+		if err != nil {
+			return nil, err
+		}
+		return squirrel.Expr("NOT (?)", pred), nil
+	case filter.Restriction:
+		return restrictionPredicate(e)
+	}
+	return nil, fmt.Errorf("unsupported expression %T", expr)
+}
+
+func restrictionPredicate(r filter.Restriction) (squirrel.Sqlizer, error) {
+	field, ok := listFields[r.Field]
+	if !ok {
+		return nil, fmt.Errorf("unknown field %q", r.Field)
+	}
+
+	if r.Op == filter.OpHas {
+		if field.kind != stringField {
+			return nil, fmt.Errorf("operator ':' is not supported for field %q", r.Field)
+		}
+		return squirrel.ILike{field.column: "%" + escapeLike(r.Value) + "%"}, nil
+	}
+
+	value, err := field.parseValue(r.Field, r.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	switch r.Op {
+	case filter.OpEq:
+		return squirrel.Eq{field.column: value}, nil
+	case filter.OpNe:
+		return squirrel.NotEq{field.column: value}, nil
+	case filter.OpLt:
+		return squirrel.Lt{field.column: value}, nil
+	case filter.OpLe:
+		return squirrel.LtOrEq{field.column: value}, nil
+	case filter.OpGt:
+		return squirrel.Gt{field.column: value}, nil
+	case filter.OpGe:
+		return squirrel.GtOrEq{field.column: value}, nil
+	}
+	return nil, fmt.Errorf("unsupported operator %q", r.Op)
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"rpc/internal/filter"
+	"rpc/internal/repository"
+)
+
+func testRepository() *orderRepository {
+	return &orderRepository{builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)}
+}
+
+func TestFilterPredicate(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   string
+		wantSQL  string
+		wantArgs []any
+		wantErr  string
+	}{
+		{
+			name:     "comparison",
+			filter:   "quantity >= 5",
+			wantSQL:  "quantity >= ?",
+			wantArgs: []any{int32(5)},
+		},
+		{
+			name:     "and, or, not",
+			filter:   `item = "Laptop" AND (quantity < 3 OR NOT id = "a")`,
+			wantSQL:  "(item = ? AND (quantity < ? OR NOT (id = ?)))",
+			wantArgs: []any{"Laptop", int32(3), "a"},
+		},
+		{
+			name:     "has escapes like wildcards",
+			filter:   `item:"50%_off"`,
+			wantSQL:  "item ILIKE ?",
+			wantArgs: []any{`%50\%\_off%`},
+		},
+		{name: "unknown field", filter: "price > 1", wantErr: `unknown field "price"`},
+		{name: "bad integer", filter: "quantity = many", wantErr: `field "quantity" expects an integer`},
+		{name: "bad timestamp", filter: "create_time > yesterday", wantErr: "expects an RFC 3339 timestamp"},
+		{name: "has on a number", filter: "quantity:5", wantErr: "operator ':' is not supported"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := filter.Parse(tt.filter)
+			if err != nil {
+				t.Fatalf("filter.Parse(%q) error: %v", tt.filter, err)
+			}
+			pred, err := filterPredicate(expr)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("filterPredicate() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("filterPredicate() error: %v", err)
+			}
+			sql, args, err := pred.ToSql()
+			if err != nil {
+				t.Fatalf("ToSql() error: %v", err)
+			}
+			if sql != tt.wantSQL || !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("filterPredicate() = %q %v, want %q %v", sql, args, tt.wantSQL, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestListQueryOrderBy(t *testing.T) {
+	tests := []struct {
+		orderBy string
+		want    string
+		wantErr string
+	}{
+		{orderBy: "", want: "ORDER BY created_at, id"},
+		{orderBy: "quantity desc", want: "ORDER BY quantity DESC, id"},
+		{orderBy: "id desc, item", want: "ORDER BY id DESC, item"},
+		{orderBy: "price", wantErr: `invalid order_by: unknown field "price"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.orderBy, func(t *testing.T) {
+			builder, _, err := testRepository().listQuery(repository.ListOptions{PageSize: 10, OrderBy: tt.orderBy})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("listQuery() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("listQuery() error: %v", err)
+			}
+			sql, _, err := builder.ToSql()
+			if err != nil {
+				t.Fatalf("ToSql() error: %v", err)
+			}
+			if !strings.Contains(sql+" ", tt.want+" ") {
+				t.Errorf("listQuery() = %q, want %q", sql, tt.want)
+			}
+		})
+	}
+}
+
+// Токен страницы привязан к filter, order_by и show_deleted запроса, в котором он выдан.
+func TestListQueryPageToken(t *testing.T) {
+	token := encodePageToken(listToken, listCursor{
+		Filter:  "quantity > 1",
+		OrderBy: "quantity desc",
+		Values:  []string{"5", "order-1"},
+	})
+
+	tests := []struct {
+		name    string
+		opts    repository.ListOptions
+		wantErr bool
+	}{
+		{name: "same query", opts: repository.ListOptions{Filter: "quantity > 1", OrderBy: "quantity desc"}},
+		{name: "other filter", opts: repository.ListOptions{Filter: "quantity > 2", OrderBy: "quantity desc"}, wantErr: true},
+		{name: "other order_by", opts: repository.ListOptions{Filter: "quantity > 1"}, wantErr: true},
+		{name: "with deleted", opts: repository.ListOptions{Filter: "quantity > 1", OrderBy: "quantity desc", ShowDeleted: true}, wantErr: true},
+		{name: "search token", opts: repository.ListOptions{Filter: "quantity > 1", OrderBy: "quantity desc", PageToken: encodePageToken(searchToken, searchCursor{Rank: 5, ID: "order-1"})}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.PageSize = 10
+			if tt.opts.PageToken == "" {
+				tt.opts.PageToken = token
+			}
+			builder, _, err := testRepository().listQuery(tt.opts)
+			if tt.wantErr {
+				if err == nil || err.Error() != "invalid page token" {
+					t.Fatalf("listQuery() error = %v, want invalid page token", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("listQuery() error: %v", err)
+			}
+			_, args, err := builder.ToSql()
+			if err != nil {
+				t.Fatalf("ToSql() error: %v", err)
+			}
+			if want := []any{int32(1), int32(5), int32(5), "order-1"}; !reflect.DeepEqual(args, want) {
+				t.Errorf("listQuery() args = %v, want %v", args, want)
+			}
+		})
+	}
+
+	if _, _, err := testRepository().listQuery(repository.ListOptions{PageSize: 10, PageToken: "!!"}); err == nil {
+		t.Error("listQuery() with a malformed token error = nil, want error")
+	}
+}
@@ -0,0 +1,25 @@
+package postgres
+
+import (
+	"google.golang.org/genproto/googleapis/type/money"
+	"rpc/internal/currency"
+)
+
+// moneyToDB раскладывает сумму в пару колонок (*_minor, currency); nil - обе NULL.
+func moneyToDB(m *money.Money) (any, any, error) {
+	if m == nil {
+		return nil, nil, nil
+	}
+	minor, err := currency.ToMinor(m)
+	if err != nil {
+		return nil, nil, err
+	}
+	return minor, m.CurrencyCode, nil
+}
+
+func moneyFromDB(minor *int64, code *string) *money.Money {
+	if minor == nil || code == nil {
+		return nil
+	}
+	return currency.FromMinor(*code, *minor)
+}
@@ -0,0 +1,18 @@
+package postgres
+
+import (
+	"strings"
+
+	"rpc/pkg/api/test"
+)
+
+// В базе статус хранится без префикса enum: ORDER_STATUS_PAID -> PAID.
+const statusPrefix = "ORDER_STATUS_"
+
+func statusToDB(s test.OrderStatus) string {
+	return strings.TrimPrefix(s.String(), statusPrefix)
+}
+
+func statusFromDB(s string) test.OrderStatus {
+	return test.OrderStatus(test.OrderStatus_value[statusPrefix+s])
+}
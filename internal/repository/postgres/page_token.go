@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// Виды токенов страниц: токен одной выдачи не принимается другой.
+const (
+	listToken    = "list"
+	searchToken  = "search"
+	historyToken = "history"
+	stockToken   = "stock"
+)
+
+// listCursor - позиция в выдаче List. Values хранит значения ключей сортировки
+// последней отданной строки (последний ключ - всегда id). Остальные поля - параметры
+// запроса, в котором выдан токен: к другому запросу его применить нельзя.
+type listCursor struct {
+	Customer    string   `json:"c,omitempty"`
+	Filter      string   `json:"f,omitempty"`
+	Labels      string   `json:"l,omitempty"`
+	OrderBy     string   `json:"o,omitempty"`
+	ShowDeleted bool     `json:"d,omitempty"`
+	Values      []string `json:"v"`
+}
+
+// searchCursor - ранг и id последнего отданного заказа в выдаче Search.
+type searchCursor struct {
+	Query       string  `json:"q"`
+	ShowDeleted bool    `json:"d,omitempty"`
+	Rank        float32 `json:"r"`
+	ID          string  `json:"i"`
+}
+
+// historyCursor - последняя отданная запись order_events заказа.
+type historyCursor struct {
+	OrderID string `json:"i"`
+	EventID int64  `json:"e"`
+}
+
+// stockCursor - последняя отданная позиция ListStock.
+type stockCursor struct {
+	Item string `json:"i"`
+}
+
+// pageToken - содержимое токена: вид выдачи и позиция в ней.
+type pageToken struct {
+	Kind   string          `json:"k"`
+	Cursor json.RawMessage `json:"c"`
+}
+
+func encodePageToken(kind string, cursor any) string {
+	c, _ := json.Marshal(cursor)
+	data, _ := json.Marshal(pageToken{Kind: kind, Cursor: c})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodePageToken разбирает токен вида kind в cursor.
+func decodePageToken(token, kind string, cursor any) error {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return fmt.Errorf("decode page token: %w", err)
+	}
+	var t pageToken
+	if err := json.Unmarshal(data, &t); err != nil {
+		return fmt.Errorf("unmarshal page token: %w", err)
+	}
+	if t.Kind != kind {
+		return fmt.Errorf("page token of %q, want %q", t.Kind, kind)
+	}
+	if err := json.Unmarshal(t.Cursor, cursor); err != nil {
+		return fmt.Errorf("unmarshal page token: %w", err)
+	}
+	return nil
+}
@@ -2,14 +2,18 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/Masterminds/squirrel"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"rpc/internal/repository"
 	"rpc/pkg/api/test"
+	"time"
 )
 
 type orderRepository struct {
@@ -24,114 +28,658 @@ func NewOrderRepository(db *pgxpool.Pool) repository.OrderRepository {
 	}
 }
 
+func NewOrderPurger(db *pgxpool.Pool) repository.OrderPurger {
+	return &orderRepository{
+		db:      db,
+		builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
 func (r *orderRepository) Create(ctx context.Context, order *test.Order) error {
-	query, args, err := r.builder.Insert("orders").
-		Columns("id", "item", "quantity").
-		Values(order.Id, order.Item, order.Quantity).
-		ToSql()
+	// шапка и позиции пишутся в одной транзакции
+	tx, err := r.db.Begin(ctx)
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback(ctx)
+
+	if err := r.insertOrder(ctx, tx, order); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// CreateWithKey создаёт заказ и запись ключа идемпотентности в одной транзакции.
+// Параллельный запрос с тем же ключом ждёт на уникальном индексе, пока первый не завершится.
+func (r *orderRepository) CreateWithKey(ctx context.Context, order *test.Order, key repository.IdempotencyKey) (string, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	// просроченный ключ можно занять заново
+	query, args, err := r.builder.Insert("idempotency_keys").
+		Columns("key", "request_hash", "order_id", "expires_at").
+		Values(key.Key, key.RequestHash, order.Id, squirrel.Expr("NOW() + make_interval(secs => ?)", key.TTL.Seconds())).
+		Suffix("ON CONFLICT (key) DO UPDATE SET " +
+			"request_hash = EXCLUDED.request_hash, order_id = EXCLUDED.order_id, " +
+			"created_at = NOW(), expires_at = EXCLUDED.expires_at " +
+			"WHERE idempotency_keys.expires_at < NOW()").
+		ToSql()
+	if err != nil {
+		return "", err
+	}
+
+	result, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return "", err
+	}
+
+	if result.RowsAffected() == 0 {
+		return r.replayKey(ctx, tx, key)
+	}
+
+	if err := r.insertOrder(ctx, tx, order); err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return "", err
+	}
+
+	return order.Id, nil
+}
+
+func (r *orderRepository) replayKey(ctx context.Context, q querier, key repository.IdempotencyKey) (string, error) {
+	query, args, err := r.builder.Select("request_hash", "order_id").
+		From("idempotency_keys").
+		Where(squirrel.Eq{"key": key.Key}).
+		ToSql()
+	if err != nil {
+		return "", err
+	}
+
+	var hash, orderID string
+	if err := q.QueryRow(ctx, query, args...).Scan(&hash, &orderID); err != nil {
+		return "", err
+	}
+
+	if hash != key.RequestHash {
+		return "", status.Errorf(codes.InvalidArgument, "idempotency key %q was already used with a different request", key.Key)
+	}
 
-	_, err = r.db.Exec(ctx, query, args...)
+	return orderID, nil
+}
+
+// setTimes переносит created_at и updated_at в заказ.
+func setTimes(order *test.Order, createdAt, updatedAt time.Time) {
+	order.CreateTime = timestamppb.New(createdAt)
+	order.UpdateTime = timestamppb.New(updatedAt)
+}
+
+func (r *orderRepository) insertOrder(ctx context.Context, q querier, order *test.Order) error {
+	totalMinor, totalCurrency, err := moneyToDB(order.Total)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid total: %v", err)
+	}
+
+	query, args, err := r.builder.Insert("orders").
+		Columns("id", "item", "quantity", "status", "version", "customer_id", "total_minor", "currency", "labels", "expire_at").
+		Values(order.Id, order.Item, order.Quantity, statusToDB(order.Status), order.Version, order.CustomerId, totalMinor, totalCurrency, labelsToDB(order.Labels), expireToDB(order.ExpireTime)).
+		Suffix("RETURNING created_at, updated_at").
+		ToSql()
 	if err != nil {
 		return err
 	}
 
-	return nil
+	var createdAt, updatedAt time.Time
+	if err := q.QueryRow(ctx, query, args...).Scan(&createdAt, &updatedAt); err != nil {
+		return orderExists(err, order.Id)
+	}
+	setTimes(order, createdAt, updatedAt)
+
+	if err := r.replaceLineItems(ctx, q, order); err != nil {
+		return err
+	}
+	if err := r.reserveStock(ctx, q, order); err != nil {
+		return err
+	}
+	return r.recordEvents(ctx, q, created(order))
 }
+
 func (r *orderRepository) Get(ctx context.Context, id string) (*test.Order, error) {
 	query, args, err := r.builder.Select(
-		"id", "item", "quantity").
+		"id", "item", "quantity", "status", "version", "created_at", "updated_at", "customer_id", "total_minor", "currency", "labels", "expire_at").
 		From("orders").
-		Where(squirrel.Eq{"id": id}).
+		Where(squirrel.Eq{"id": id, "deleted_at": nil}).
 		ToSql()
 	if err != nil {
 		return nil, err
 	}
 
 	var order test.Order
-	err = r.db.QueryRow(ctx, query, args...).Scan(&order.Id, &order.Item, &order.Quantity)
+	var orderStatus string
+	var createdAt, updatedAt time.Time
+	var totalMinor *int64
+	var totalCurrency *string
+	var expireAt *time.Time
+	err = r.db.QueryRow(ctx, query, args...).Scan(&order.Id, &order.Item, &order.Quantity, &orderStatus, &order.Version, &createdAt, &updatedAt, &order.CustomerId, &totalMinor, &totalCurrency, &order.Labels, &expireAt)
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return nil, status.Errorf(codes.NotFound, "order with id %s not found", id)
+			return nil, repository.OrderNotFound(id)
 		}
 		return nil, err
 	}
+	order.Status = statusFromDB(orderStatus)
+	order.Total = moneyFromDB(totalMinor, totalCurrency)
+	order.Labels = labelsFromDB(order.Labels)
+	order.ExpireTime = expireFromDB(expireAt)
+	setTimes(&order, createdAt, updatedAt)
+
+	if err := r.attachLineItems(ctx, r.db, []*test.Order{&order}); err != nil {
+		return nil, err
+	}
 
 	return &order, nil
 }
 
-func (r *orderRepository) Update(ctx context.Context, order *test.Order) error {
-	query, args, err := r.builder.Update("orders").
-		Set("item", order.Item).
-		Set("quantity", order.Quantity).
-		Where(squirrel.Eq{"id": order.Id}).
-		ToSql()
+// orderExists переводит нарушение первичного ключа orders (id, выбранный клиентом,
+// уже занят) в codes.AlreadyExists, остальные ошибки возвращает как есть. ids - id
+// вставляемых заказов: если он один, попадает в сообщение.
+func orderExists(err error, ids ...string) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != "23505" || pgErr.ConstraintName != "orders_pkey" {
+		return err
+	}
+	if len(ids) == 1 {
+		return status.Errorf(codes.AlreadyExists, "order with id %s already exists", ids[0])
+	}
+	return status.Error(codes.AlreadyExists, "one of the orders already exists")
+}
+
+// missingOrStale объясняет, почему условный UPDATE/DELETE не затронул ни одной строки:
+// заказа нет совсем или его версия уже не та, что ожидал вызывающий.
+func (r *orderRepository) missingOrStale(ctx context.Context, id string) error {
+	if _, err := r.Get(ctx, id); err != nil {
+		return err
+	}
+	return status.Errorf(codes.Aborted, "order %s was modified concurrently", id)
+}
+
+func (r *orderRepository) Update(ctx context.Context, order *test.Order, fields []string) error {
+	builder := r.builder.Update("orders").
+		Set("version", squirrel.Expr("version + 1")).
+		Where(squirrel.Eq{"id": order.Id, "deleted_at": nil}).
+		Suffix("RETURNING version, updated_at")
+	if order.Version > 0 {
+		builder = builder.Where(squirrel.Eq{"version": order.Version})
+	}
+
+	replaceLines := false
+	for _, field := range fields {
+		switch field {
+		case "item":
+			builder = builder.Set("item", order.Item)
+		case "quantity":
+			builder = builder.Set("quantity", order.Quantity)
+		case "labels":
+			builder = builder.Set("labels", labelsToDB(order.Labels))
+		case "line_items":
+			// итог считается по позициям, поэтому пишется вместе с ними
+			totalMinor, totalCurrency, err := moneyToDB(order.Total)
+			if err != nil {
+				return status.Errorf(codes.InvalidArgument, "invalid total: %v", err)
+			}
+			builder = builder.Set("total_minor", totalMinor).Set("currency", totalCurrency)
+			replaceLines = true
+		default:
+			return fmt.Errorf("field %q cannot be updated", field)
+		}
+	}
+
+	query, args, err := builder.ToSql()
 	if err != nil {
 		return err
 	}
 
-	result, err := r.db.Exec(ctx, query, args...)
+	tx, err := r.db.Begin(ctx)
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback(ctx)
 
-	if result.RowsAffected() == 0 {
-		return status.Errorf(codes.NotFound, "order with id %s not found", order.Id)
+	before, err := r.snapshot(ctx, tx, order.Id)
+	if err != nil {
+		return err
+	}
+
+	var version int64
+	var updatedAt time.Time
+	err = tx.QueryRow(ctx, query, args...).Scan(&version, &updatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return r.missingOrStale(ctx, order.Id)
+		}
+		return err
+	}
+
+	if replaceLines {
+		if err := r.replaceLineItems(ctx, tx, order); err != nil {
+			return err
+		}
+		// резерв открытого заказа пересчитывается под новые позиции
+		if prev := before[order.Id]; prev != nil && holdsStock(prev.Status) {
+			if err := r.releaseStock(ctx, tx, []string{order.Id}, true); err != nil {
+				return err
+			}
+			if err := r.reserveStock(ctx, tx, order); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := r.recordChange(ctx, tx, test.OrderHistoryAction_ORDER_HISTORY_ACTION_UPDATED, before, order.Id); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
 	}
 
+	order.Version = version
+	order.UpdateTime = timestamppb.New(updatedAt)
 	return nil
 
 }
 
-func (r *orderRepository) Delete(ctx context.Context, id string) error {
-	query, args, err := r.builder.Delete("orders").
+// Delete только помечает заказ удалённым, окончательно его убирает PurgeDeleted.
+func (r *orderRepository) Delete(ctx context.Context, id string, version int64) error {
+	builder := r.builder.Update("orders").
+		Set("deleted_at", squirrel.Expr("NOW()")).
+		Set("version", squirrel.Expr("version + 1")).
+		Where(squirrel.Eq{"id": id, "deleted_at": nil})
+	if version > 0 {
+		builder = builder.Where(squirrel.Eq{"version": version})
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	before, err := r.snapshot(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		if version > 0 {
+			return r.missingOrStale(ctx, id)
+		}
+		return repository.OrderNotFound(id)
+	}
+
+	// удалённый заказ остаток не держит: после очистки резерв ушёл бы по CASCADE
+	// вместе с ним, а available так и остался бы уменьшенным
+	if err := r.releaseStock(ctx, tx, []string{id}, true); err != nil {
+		return err
+	}
+
+	if err := r.recordChange(ctx, tx, test.OrderHistoryAction_ORDER_HISTORY_ACTION_DELETED, before, id); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *orderRepository) Undelete(ctx context.Context, id string) error {
+	query, args, err := r.builder.Update("orders").
+		Set("deleted_at", nil).
+		Set("version", squirrel.Expr("version + 1")).
 		Where(squirrel.Eq{"id": id}).
+		Where(squirrel.NotEq{"deleted_at": nil}).
 		ToSql()
 	if err != nil {
 		return err
 	}
 
-	result, err := r.db.Exec(ctx, query, args...)
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	before, err := r.snapshot(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(ctx, query, args...)
 	if err != nil {
 		return err
 	}
 
 	if result.RowsAffected() == 0 {
-		return status.Errorf(codes.NotFound, "order with id %s not found", id)
+		if _, err := r.Get(ctx, id); err != nil {
+			return err
+		}
+		return status.Errorf(codes.FailedPrecondition, "order %s is not deleted", id)
 	}
 
-	return nil
+	// открытый заказ снова резервирует остаток, снятый при удалении
+	if prev := before[id]; prev != nil && holdsStock(prev.Status) {
+		if err := r.reserveStock(ctx, tx, prev); err != nil {
+			if status.Code(err) == codes.ResourceExhausted {
+				return status.Errorf(codes.FailedPrecondition, "order %s cannot be restored: %s", id, status.Convert(err).Message())
+			}
+			return err
+		}
+	}
+
+	if err := r.recordChange(ctx, tx, test.OrderHistoryAction_ORDER_HISTORY_ACTION_UNDELETED, before, id); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
 }
 
-func (r *orderRepository) List(ctx context.Context) ([]*test.Order, error) {
-	query, args, err := r.builder.Select("id", "item", "quantity").
+// PurgeDeleted окончательно удаляет до limit заказов, помеченных удалёнными раньше,
+// чем olderThan назад. Позиции уходят вместе с заказом по ON DELETE CASCADE, в историю
+// пишется PURGED с последним состоянием заказа. Строки, которые держит другая
+// транзакция, пропускаются до следующего прохода.
+func (r *orderRepository) PurgeDeleted(ctx context.Context, olderThan time.Duration, limit int) (int64, error) {
+	query, args, err := r.builder.Select("id").
 		From("orders").
+		Where(squirrel.Expr("deleted_at < NOW() - make_interval(secs => ?)", olderThan.Seconds())).
+		Limit(uint64(limit)).
+		Suffix("FOR UPDATE SKIP LOCKED").
 		ToSql()
 	if err != nil {
-		return nil, err
+		return 0, err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return 0, fmt.Errorf("scanning order id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("iterating rows: %w", err)
+	}
+	rows.Close()
+
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	before, err := r.snapshot(ctx, tx, ids...)
+	if err != nil {
+		return 0, err
+	}
+	if err := r.recordEvents(ctx, tx, purged(ids, before)); err != nil {
+		return 0, err
+	}
+
+	query, args, err = r.builder.Delete("orders").
+		Where(squirrel.Eq{"id": ids}).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+	result, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+func (r *orderRepository) SetStatus(ctx context.Context, order *test.Order, to test.OrderStatus) error {
+	builder := r.builder.Update("orders").
+		Set("status", statusToDB(to)).
+		Set("version", squirrel.Expr("version + 1")).
+		// истекают только заказы в PENDING, а в него не возвращаются
+		Set("expire_at", nil).
+		Where(squirrel.Eq{"id": order.Id, "status": statusToDB(order.Status), "deleted_at": nil}).
+		Suffix("RETURNING version, updated_at")
+	if order.Version > 0 {
+		builder = builder.Where(squirrel.Eq{"version": order.Version})
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	before, err := r.snapshot(ctx, tx, order.Id)
+	if err != nil {
+		return err
+	}
+
+	var version int64
+	var updatedAt time.Time
+	err = tx.QueryRow(ctx, query, args...).Scan(&version, &updatedAt)
+	if err != nil {
+		if err != pgx.ErrNoRows {
+			return err
+		}
+		// либо заказа нет, либо его успели поменять параллельно
+		current, err := r.Get(ctx, order.Id)
+		if err != nil {
+			return err
+		}
+		if current.Status != order.Status {
+			return status.Errorf(codes.FailedPrecondition, "order %s is no longer in status %s", order.Id, order.Status)
+		}
+		return status.Errorf(codes.Aborted, "order %s was modified concurrently", order.Id)
+	}
+
+	// отмена возвращает резерв в остаток, отгрузка его списывает
+	switch to {
+	case test.OrderStatus_ORDER_STATUS_CANCELLED:
+		err = r.releaseStock(ctx, tx, []string{order.Id}, true)
+	case test.OrderStatus_ORDER_STATUS_SHIPPED:
+		err = r.releaseStock(ctx, tx, []string{order.Id}, false)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := r.recordChange(ctx, tx, test.OrderHistoryAction_ORDER_HISTORY_ACTION_STATUS_CHANGED, before, order.Id); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	order.Status = to
+	order.ExpireTime = nil
+	order.Version = version
+	order.UpdateTime = timestamppb.New(updatedAt)
+	return nil
+}
+
+// PurgeIdempotencyKeys удаляет до limit просроченных ключей идемпотентности.
+func (r *orderRepository) PurgeIdempotencyKeys(ctx context.Context, limit int) (int64, error) {
+	query, args, err := r.builder.Delete("idempotency_keys").
+		Where(squirrel.Expr(
+			"key IN (SELECT key FROM idempotency_keys WHERE expires_at < NOW() LIMIT ?)", limit,
+		)).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// PurgeChanges удаляет до limit записей журнала изменений старше olderThan.
+// Удаляется только начало журнала, до первой записи моложе olderThan, а его конец
+// запоминается в order_changes_purged: клиенты WatchOrders с токеном до него получат
+// OutOfRange.
+func (r *orderRepository) PurgeChanges(ctx context.Context, olderThan time.Duration, limit int) (int64, error) {
+	query, args, err := r.builder.Delete("order_changes").
+		Where(squirrel.Expr(
+			`(txid, seq) IN (
+				SELECT txid, seq FROM order_changes
+				WHERE `+stableChanges+`
+					AND (txid, seq) < ALL (
+						SELECT txid, seq FROM order_changes
+						WHERE changed_at >= NOW() - make_interval(secs => ?)
+						ORDER BY txid, seq LIMIT 1
+					)
+				ORDER BY txid, seq LIMIT ?
+			)`,
+			olderThan.Seconds(), limit,
+		)).
+		Suffix("RETURNING txid, seq").
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var purged int64
+	var last repository.Position
+	for rows.Next() {
+		var pos repository.Position
+		if err := rows.Scan(&pos.TxID, &pos.Seq); err != nil {
+			return 0, fmt.Errorf("scanning purged change: %w", err)
+		}
+		if last.Before(pos) {
+			last = pos
+		}
+		purged++
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("iterating purged changes: %w", err)
+	}
+	rows.Close()
+
+	if purged == 0 {
+		return 0, nil
+	}
+
+	query, args, err = r.builder.Insert("order_changes_purged").
+		Columns("txid", "seq").
+		Values(last.TxID, last.Seq).
+		// очистку могут вести несколько экземпляров, отметка только растёт
+		Suffix(`ON CONFLICT (id) DO UPDATE SET txid = EXCLUDED.txid, seq = EXCLUDED.seq
+			WHERE (order_changes_purged.txid, order_changes_purged.seq) < (EXCLUDED.txid, EXCLUDED.seq)`).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return 0, fmt.Errorf("record purged changes: %w", err)
+	}
+
+	return purged, tx.Commit(ctx)
+}
+
+func (r *orderRepository) List(ctx context.Context, opts repository.ListOptions) ([]*test.Order, string, error) {
+	builder, keys, err := r.listQuery(opts)
+	if err != nil {
+		return nil, "", status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	query, args, err := builder.Limit(uint64(opts.PageSize) + 1).ToSql()
+	if err != nil {
+		return nil, "", err
 	}
 
 	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	defer rows.Close()
 
 	var orders []*test.Order
+	var last listRow
+	var next string
 	for rows.Next() {
-		var order test.Order
-		err := rows.Scan(&order.Id, &order.Item, &order.Quantity)
+		row, err := scanListRow(rows)
 		if err != nil {
-			return nil, fmt.Errorf("scanning order: %w", err)
+			return nil, "", err
+		}
+		if len(orders) == int(opts.PageSize) {
+			// лишняя строка только сигнализирует, что есть следующая страница
+			next = encodePageToken(listToken, listCursor{
+				Customer:    opts.CustomerID,
+				Filter:      opts.Filter,
+				Labels:      opts.LabelSelector,
+				OrderBy:     opts.OrderBy,
+				ShowDeleted: opts.ShowDeleted,
+				Values:      cursorValues(keys, last),
+			})
+			break
 		}
-		orders = append(orders, &order)
+		orders = append(orders, row.order)
+		last = row
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterating rows: %w", err)
+		return nil, "", fmt.Errorf("iterating rows: %w", err)
+	}
+	rows.Close()
+
+	if err := r.attachLineItems(ctx, r.db, orders); err != nil {
+		return nil, "", err
 	}
 
-	return orders, nil
+	return orders, next, nil
 }
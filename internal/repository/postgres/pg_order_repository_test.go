@@ -0,0 +1,45 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestOrderExists(t *testing.T) {
+	duplicate := &pgconn.PgError{Code: "23505", ConstraintName: "orders_pkey", Detail: "Key (id)=(order-1) already exists."}
+	other := errors.New("connection reset")
+
+	tests := []struct {
+		name string
+		err  error
+		ids  []string
+		want string
+	}{
+		{name: "single order", err: duplicate, ids: []string{"order-1"}, want: "order with id order-1 already exists"},
+		{name: "wrapped", err: fmt.Errorf("copy orders: %w", duplicate), ids: []string{"order-1"}, want: "order with id order-1 already exists"},
+		{name: "batch", err: duplicate, ids: []string{"order-1", "order-2"}, want: "one of the orders already exists"},
+		{name: "other constraint", err: &pgconn.PgError{Code: "23505", ConstraintName: "idempotency_keys_pkey"}},
+		{name: "other error", err: other},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := orderExists(tt.err, tt.ids...)
+			if tt.want == "" {
+				if got != tt.err {
+					t.Errorf("orderExists() = %v, want the error unchanged", got)
+				}
+				return
+			}
+			st := status.Convert(got)
+			if st.Code() != codes.AlreadyExists || st.Message() != tt.want {
+				t.Errorf("orderExists() = %s %q, want AlreadyExists %q", st.Code(), st.Message(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/Masterminds/squirrel"
+	"rpc/internal/repository"
+	"rpc/pkg/api/test"
+)
+
+// reservation - сколько заказ держит из остатка одной позиции.
+type reservation struct {
+	orderID  string
+	item     string
+	quantity int64
+}
+
+// holdsStock - держит ли заказ в этом статусе резерв: до отгрузки и если не отменён.
+func holdsStock(s test.OrderStatus) bool {
+	switch s {
+	case test.OrderStatus_ORDER_STATUS_PENDING, test.OrderStatus_ORDER_STATUS_CONFIRMED, test.OrderStatus_ORDER_STATUS_PAID:
+		return true
+	}
+	return false
+}
+
+// demand складывает количество по позициям каждого заказа в порядке заказов и позиций.
+func demand(orders []*test.Order) []reservation {
+	var out []reservation
+	for _, order := range orders {
+		index := make(map[string]int)
+		for _, line := range order.LineItems {
+			if i, ok := index[line.Item]; ok {
+				out[i].quantity += int64(line.Quantity)
+				continue
+			}
+			index[line.Item] = len(out)
+			out = append(out, reservation{orderID: order.Id, item: line.Item, quantity: int64(line.Quantity)})
+		}
+	}
+	return out
+}
+
+// reserveStock списывает из available остатки отслеживаемых позиций под заказы и
+// записывает резервы. Позиции без записи в inventory не ограничены. Если хоть одной
+// позиции не хватает, возвращает repository.InsufficientStock и ничего не пишет.
+// Строки inventory блокируются в порядке item, чтобы параллельные заказы не
+// взаимоблокировались.
+func (r *orderRepository) reserveStock(ctx context.Context, q querier, orders ...*test.Order) error {
+	wanted := demand(orders)
+	if len(wanted) == 0 {
+		return nil
+	}
+
+	total := make(map[string]int64)
+	for _, res := range wanted {
+		total[res.item] += res.quantity
+	}
+	items := make([]string, 0, len(total))
+	for item := range total {
+		items = append(items, item)
+	}
+	slices.Sort(items)
+
+	query, args, err := r.builder.Select("item", "available").
+		From("inventory").
+		Where(squirrel.Eq{"item": items}).
+		OrderBy("item").
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	available := make(map[string]int64)
+	var tracked []string
+	var shortages []repository.StockShortage
+	for rows.Next() {
+		var item string
+		var n int64
+		if err := rows.Scan(&item, &n); err != nil {
+			return fmt.Errorf("scanning stock: %w", err)
+		}
+		available[item] = n
+		tracked = append(tracked, item)
+		if total[item] > n {
+			shortages = append(shortages, repository.StockShortage{Item: item, Requested: total[item], Available: n})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterating stock: %w", err)
+	}
+	rows.Close()
+
+	if len(shortages) > 0 {
+		return repository.InsufficientStock(shortages)
+	}
+
+	if len(tracked) > 0 {
+		deltas := make([]int64, len(tracked))
+		for i, item := range tracked {
+			deltas[i] = -total[item]
+		}
+		if err := r.addStock(ctx, q, tracked, deltas); err != nil {
+			return fmt.Errorf("reserve stock: %w", err)
+		}
+	}
+
+	var held []reservation
+	for _, res := range wanted {
+		if _, ok := available[res.item]; ok {
+			held = append(held, res)
+		}
+	}
+
+	// резервы импорта могут не уложиться в лимит параметров одного INSERT
+	for len(held) > 0 {
+		n := min(len(held), maxInsertRows)
+		insert := r.builder.Insert("inventory_reservations").
+			Columns("order_id", "item", "quantity")
+		for _, res := range held[:n] {
+			insert = insert.Values(res.orderID, res.item, res.quantity)
+		}
+		held = held[n:]
+
+		query, args, err := insert.ToSql()
+		if err != nil {
+			return err
+		}
+		if _, err := q.Exec(ctx, query, args...); err != nil {
+			return fmt.Errorf("insert reservations: %w", err)
+		}
+	}
+	return nil
+}
+
+// addStock одним UPDATE прибавляет к available каждой позиции items свой delta.
+func (r *orderRepository) addStock(ctx context.Context, q querier, items []string, deltas []int64) error {
+	changes := r.builder.Select().
+		Column("unnest(?::text[]) AS item", items).
+		Column("unnest(?::bigint[]) AS delta", deltas)
+	query, args, err := r.builder.Update("inventory i").
+		Set("available", squirrel.Expr("i.available + d.delta")).
+		FromSelect(changes, "d").
+		Where("i.item = d.item").
+		ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = q.Exec(ctx, query, args...)
+	return err
+}
+
+// releaseStock снимает резервы заказов ids. restore возвращает их в available (отмена),
+// без него резерв просто списывается (отгрузка). Число запросов не зависит от числа
+// заказов: остатки возвращаются одним UPDATE по сумме резервов каждой позиции.
+func (r *orderRepository) releaseStock(ctx context.Context, q querier, ids []string, restore bool) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	ofOrders := squirrel.Expr("order_id = ANY(?)", ids)
+
+	if restore {
+		// строки inventory блокируются в порядке item, как в reserveStock
+		query, args, err := r.builder.Select("item").
+			From("inventory").
+			Where(squirrel.Expr("item IN (SELECT item FROM inventory_reservations WHERE order_id = ANY(?))", ids)).
+			OrderBy("item").
+			Suffix("FOR UPDATE").
+			ToSql()
+		if err != nil {
+			return err
+		}
+		if _, err := q.Exec(ctx, query, args...); err != nil {
+			return fmt.Errorf("lock stock: %w", err)
+		}
+
+		released := r.builder.Select("item", "sum(quantity) AS quantity").
+			From("inventory_reservations").
+			Where(ofOrders).
+			GroupBy("item")
+		query, args, err = r.builder.Update("inventory i").
+			Set("available", squirrel.Expr("i.available + r.quantity")).
+			FromSelect(released, "r").
+			Where("i.item = r.item").
+			ToSql()
+		if err != nil {
+			return err
+		}
+		if _, err := q.Exec(ctx, query, args...); err != nil {
+			return fmt.Errorf("release stock: %w", err)
+		}
+	}
+
+	query, args, err := r.builder.Delete("inventory_reservations").
+		Where(ofOrders).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	if _, err := q.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("delete reservations: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// recordingQuerier запоминает запросы Exec; Query и QueryRow тестам не нужны.
+type recordingQuerier struct {
+	querier
+	queries []string
+	args    [][]any
+}
+
+func (q *recordingQuerier) Exec(_ context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	q.queries = append(q.queries, sql)
+	q.args = append(q.args, args)
+	return pgconn.CommandTag{}, nil
+}
+
+func TestAddStock(t *testing.T) {
+	var q recordingQuerier
+	if err := testRepository().addStock(context.Background(), &q, []string{"Laptop", "Tablet"}, []int64{-2, -3}); err != nil {
+		t.Fatalf("addStock() error: %v", err)
+	}
+
+	want := []string{
+		"UPDATE inventory i SET available = i.available + d.delta FROM (SELECT unnest($1::text[]) AS item, unnest($2::bigint[]) AS delta) AS d WHERE i.item = d.item",
+	}
+	if !reflect.DeepEqual(q.queries, want) {
+		t.Errorf("queries = %q, want %q", q.queries, want)
+	}
+	if wantArgs := []any{[]string{"Laptop", "Tablet"}, []int64{-2, -3}}; !reflect.DeepEqual(q.args[0], wantArgs) {
+		t.Errorf("args = %v, want %v", q.args[0], wantArgs)
+	}
+}
+
+func TestReleaseStock(t *testing.T) {
+	ids := []string{"order-1", "order-2"}
+	tests := []struct {
+		name    string
+		ids     []string
+		restore bool
+		want    []string
+	}{
+		{
+			name: "no orders",
+		},
+		{
+			name: "ship",
+			ids:  ids,
+			want: []string{
+				"DELETE FROM inventory_reservations WHERE order_id = ANY($1)",
+			},
+		},
+		{
+			name:    "cancel",
+			ids:     ids,
+			restore: true,
+			want: []string{
+				"SELECT item FROM inventory WHERE item IN (SELECT item FROM inventory_reservations WHERE order_id = ANY($1)) ORDER BY item FOR UPDATE",
+				"UPDATE inventory i SET available = i.available + r.quantity FROM (SELECT item, sum(quantity) AS quantity FROM inventory_reservations WHERE order_id = ANY($1) GROUP BY item) AS r WHERE i.item = r.item",
+				"DELETE FROM inventory_reservations WHERE order_id = ANY($1)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var q recordingQuerier
+			if err := testRepository().releaseStock(context.Background(), &q, tt.ids, tt.restore); err != nil {
+				t.Fatalf("releaseStock() error: %v", err)
+			}
+			if !reflect.DeepEqual(q.queries, tt.want) {
+				t.Errorf("queries = %q, want %q", q.queries, tt.want)
+			}
+			for i, args := range q.args {
+				if !reflect.DeepEqual(args, []any{tt.ids}) {
+					t.Errorf("query %d args = %v, want %v", i, args, []any{tt.ids})
+				}
+			}
+		})
+	}
+}
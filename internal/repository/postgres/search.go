@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/Masterminds/squirrel"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"rpc/internal/repository"
+	"rpc/pkg/api/test"
+)
+
+// searchTerms разбивает текст поиска на слова и превращает каждое в префиксный
+// терм tsquery. В словах остаются только буквы и цифры, так что синтаксис
+// tsquery из пользовательского текста попасть в запрос не может.
+func searchTerms(q string) []string {
+	words := strings.FieldsFunc(strings.ToLower(q), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	terms := make([]string, 0, len(words))
+	for _, w := range words {
+		terms = append(terms, w+":*")
+	}
+	return terms
+}
+
+// Search ранжирует заказ по лучшей из подходящих позиций (ts_rank по order_items.search_vector).
+// Страницы режутся по (rank, id), как и в List, без OFFSET.
+func (r *orderRepository) Search(ctx context.Context, opts repository.SearchOptions) ([]*test.Order, string, error) {
+	terms := searchTerms(opts.Query)
+	if len(terms) == 0 {
+		return nil, "", status.Error(codes.InvalidArgument, "search query has no words")
+	}
+	tsquery := strings.Join(terms, " & ")
+
+	matches := r.builder.Select("order_id").
+		Column(squirrel.Expr("max(ts_rank(search_vector, to_tsquery('simple', ?))) AS rank", tsquery)).
+		From("order_items").
+		Where("search_vector @@ to_tsquery('simple', ?)", tsquery).
+		GroupBy("order_id")
+
+	columns := make([]string, 0, len(listColumns)+1)
+	for _, c := range listColumns {
+		columns = append(columns, "o."+c)
+	}
+	builder := r.builder.Select(append(columns, "m.rank")...).
+		FromSelect(matches, "m").
+		Join("orders o ON o.id = m.order_id").
+		OrderBy("m.rank DESC", "o.id")
+
+	if !opts.ShowDeleted {
+		builder = builder.Where(squirrel.Eq{"o.deleted_at": nil})
+	}
+
+	if opts.PageToken != "" {
+		var cursor searchCursor
+		err := decodePageToken(opts.PageToken, searchToken, &cursor)
+		if err != nil || cursor.Query != opts.Query || cursor.ShowDeleted != opts.ShowDeleted || cursor.ID == "" {
+			return nil, "", status.Error(codes.InvalidArgument, "invalid page token")
+		}
+		builder = builder.Where(squirrel.Or{
+			squirrel.Lt{"m.rank": cursor.Rank},
+			squirrel.And{squirrel.Eq{"m.rank": cursor.Rank}, squirrel.Gt{"o.id": cursor.ID}},
+		})
+	}
+
+	query, args, err := builder.Limit(uint64(opts.PageSize) + 1).ToSql()
+	if err != nil {
+		return nil, "", err
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	var orders []*test.Order
+	var next string
+	var lastRank, rank float32
+	for rows.Next() {
+		row, err := scanListRow(rows, &rank)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(orders) == int(opts.PageSize) {
+			last := orders[len(orders)-1]
+			next = encodePageToken(searchToken, searchCursor{
+				Query:       opts.Query,
+				ShowDeleted: opts.ShowDeleted,
+				Rank:        lastRank,
+				ID:          last.Id,
+			})
+			break
+		}
+		orders = append(orders, row.order)
+		lastRank = rank
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("iterating rows: %w", err)
+	}
+	rows.Close()
+
+	if err := r.attachLineItems(ctx, r.db, orders); err != nil {
+		return nil, "", err
+	}
+	return orders, next, nil
+}
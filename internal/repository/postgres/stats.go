@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"rpc/internal/repository"
+	"rpc/pkg/api/test"
+)
+
+// Stats считает статистику агрегатами в базе: гистограмму по дням UTC (с пустыми днями
+// из generate_series) и самые заказываемые позиции. Оба запроса идут в одной
+// read-only транзакции, чтобы итоги и разбивка по позициям сходились.
+func (r *orderRepository) Stats(ctx context.Context, opts repository.StatsOptions) (*test.OrderStats, error) {
+	from, to := opts.From.UTC(), opts.To.UTC()
+
+	days, args, err := r.builder.Select("d.day", "count(o.id)", "COALESCE(sum(o.quantity), 0)").
+		From("generate_series(date_trunc('day', ?::timestamp), ?::timestamp - interval '1 microsecond', interval '1 day') AS d(day)").
+		JoinClause(squirrel.Expr(
+			"LEFT JOIN orders o ON o.created_at >= GREATEST(d.day, ?::timestamp) AND o.created_at < LEAST(d.day + interval '1 day', ?::timestamp) AND o.deleted_at IS NULL",
+			from, to,
+		)).
+		GroupBy("d.day").
+		OrderBy("d.day").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+	// аргументы generate_series стоят в From, куда squirrel их не принимает, а в запросе они первые
+	args = append([]any{from, to}, args...)
+
+	items, itemArgs, err := r.builder.Select("oi.item", "count(DISTINCT oi.order_id)", "sum(oi.quantity)").
+		From("order_items oi").
+		Join("orders o ON o.id = oi.order_id").
+		Where(squirrel.Eq{"o.deleted_at": nil}).
+		Where(squirrel.GtOrEq{"o.created_at": from}).
+		Where(squirrel.Lt{"o.created_at": to}).
+		GroupBy("oi.item").
+		OrderBy("3 DESC", "oi.item").
+		Limit(uint64(opts.ItemLimit)).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	stats := &test.OrderStats{
+		StartTime: timestamppb.New(from),
+		EndTime:   timestamppb.New(to),
+	}
+
+	rows, err := tx.Query(ctx, days, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query day stats: %w", err)
+	}
+	for rows.Next() {
+		var day time.Time
+		bucket := &test.DayStats{}
+		if err := rows.Scan(&day, &bucket.Orders, &bucket.Quantity); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scanning day stats: %w", err)
+		}
+		bucket.StartTime = timestamppb.New(day)
+		stats.Days = append(stats.Days, bucket)
+		stats.TotalOrders += bucket.Orders
+		stats.TotalQuantity += bucket.Quantity
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating day stats: %w", err)
+	}
+
+	rows, err = tx.Query(ctx, items, itemArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("query item stats: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		item := &test.ItemStats{}
+		if err := rows.Scan(&item.Item, &item.Orders, &item.Quantity); err != nil {
+			return nil, fmt.Errorf("scanning item stats: %w", err)
+		}
+		stats.Items = append(stats.Items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating item stats: %w", err)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"rpc/internal/repository"
+	"rpc/pkg/api/test"
+)
+
+// streamFetchSize - сколько строк за раз забирать из курсора.
+const streamFetchSize = 1000
+
+// Stream проходит по всем подходящим заказам серверным курсором и отдаёт их в send
+// по одному, держа в памяти не больше streamFetchSize заказов. Весь проход идёт в
+// одной read-only транзакции, так что выгрузка видит согласованный снимок.
+// PageSize и PageToken игнорируются.
+func (r *orderRepository) Stream(ctx context.Context, opts repository.ListOptions, send func(*test.Order) error) error {
+	builder, _, err := r.listQuery(repository.ListOptions{
+		Filter:        opts.Filter,
+		LabelSelector: opts.LabelSelector,
+		OrderBy:       opts.OrderBy,
+		ShowDeleted:   opts.ShowDeleted,
+	})
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, "DECLARE orders_stream NO SCROLL CURSOR FOR "+query, args...); err != nil {
+		return fmt.Errorf("declare cursor: %w", err)
+	}
+
+	fetch := fmt.Sprintf("FETCH %d FROM orders_stream", streamFetchSize)
+	for {
+		orders, err := fetchOrders(ctx, tx, fetch)
+		if err != nil {
+			return err
+		}
+		if len(orders) == 0 {
+			break
+		}
+
+		if err := r.attachLineItems(ctx, tx, orders); err != nil {
+			return err
+		}
+
+		for _, order := range orders {
+			if err := send(order); err != nil {
+				return err
+			}
+		}
+
+		if len(orders) < streamFetchSize {
+			break
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func fetchOrders(ctx context.Context, tx pgx.Tx, fetch string) ([]*test.Order, error) {
+	rows, err := tx.Query(ctx, fetch)
+	if err != nil {
+		return nil, fmt.Errorf("fetch: %w", err)
+	}
+	defer rows.Close()
+
+	orders := make([]*test.Order, 0, streamFetchSize)
+	for rows.Next() {
+		row, err := scanListRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, row.order)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating rows: %w", err)
+	}
+
+	return orders, nil
+}
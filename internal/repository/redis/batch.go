@@ -0,0 +1,106 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+	"rpc/internal/repository"
+	"rpc/pkg/api/test"
+)
+
+// BatchCreate кладёт заказы в кэш одним пайплайном.
+func (r *orderRepository) BatchCreate(ctx context.Context, orders []*test.Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, order := range orders {
+			fields, err := orderHash(order)
+			if err != nil {
+				return err
+			}
+			pipe.HSet(ctx, orderKey(order.Id), fields...)
+			pipe.Expire(ctx, orderKey(order.Id), orderTTL)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("redis batch create: %w", err)
+	}
+
+	return nil
+}
+
+// GetMany достаёт из кэша те заказы, что там есть, одним пайплайном HGETALL.
+// Отсутствующие и нечитаемые записи просто не попадают в результат.
+func (r *orderRepository) GetMany(ctx context.Context, ids []string) (map[string]*test.Order, error) {
+	found := make(map[string]*test.Order, len(ids))
+	if len(ids) == 0 {
+		return found, nil
+	}
+
+	cmds := make([]*redis.MapStringStringCmd, len(ids))
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, id := range ids {
+			cmds[i] = pipe.HGetAll(ctx, orderKey(id))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("redis batch get: %w", err)
+	}
+
+	for i, cmd := range cmds {
+		values := cmd.Val()
+		if len(values) == 0 {
+			continue
+		}
+		order, err := decodeOrder(values)
+		if err != nil {
+			continue
+		}
+		found[ids[i]] = order
+	}
+
+	return found, nil
+}
+
+func (r *orderRepository) BatchGet(ctx context.Context, ids []string) ([]*test.Order, error) {
+	found, err := r.GetMany(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	orders := make([]*test.Order, len(ids))
+	var missing []string
+	for i, id := range ids {
+		if orders[i] = found[id]; orders[i] == nil {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, repository.OrdersNotFound(missing)
+	}
+
+	return orders, nil
+}
+
+func (r *orderRepository) BatchDelete(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = orderKey(id)
+	}
+
+	return r.client.Del(ctx, keys...).Err()
+}
+
+// Import ничего не кэширует: импортированные заказы попадут в кэш при первом чтении.
+func (r *orderRepository) Import(ctx context.Context, orders []*test.Order) error {
+	return nil
+}
@@ -2,7 +2,9 @@ package redis
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
@@ -11,6 +13,8 @@ import (
 	"rpc/pkg/api/test"
 
 	"github.com/redis/go-redis/v9"
+	"google.golang.org/genproto/googleapis/type/money"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type orderRepository struct {
@@ -23,25 +27,161 @@ func NewOrderRepository(client *redis.Client) repository.OrderRepository {
 	}
 }
 
-func (r *orderRepository) Create(ctx context.Context, order *test.Order) error {
-	key := "order:" + order.Id
+// orderTTL - сколько заказ живёт в кэше
+const orderTTL = 10 * time.Minute
+
+// orderKey - ключ хэша заказа. Версия в ключе меняется вместе с набором полей хэша,
+// так что записи старого формата не читаются, а доживают свой TTL.
+func orderKey(id string) string {
+	return "order:v2:" + id
+}
 
-	err := r.client.HSet(ctx, key,
+// orderHash раскладывает заказ в поля хэша orderKey.
+func orderHash(order *test.Order) ([]any, error) {
+	lineItems, err := json.Marshal(order.LineItems)
+	if err != nil {
+		return nil, fmt.Errorf("marshal line items: %w", err)
+	}
+
+	total, err := json.Marshal(order.Total)
+	if err != nil {
+		return nil, fmt.Errorf("marshal total: %w", err)
+	}
+
+	labels, err := json.Marshal(order.Labels)
+	if err != nil {
+		return nil, fmt.Errorf("marshal labels: %w", err)
+	}
+
+	return []any{
 		"id", order.Id,
 		"item", order.Item,
 		"quantity", order.Quantity,
-	).Err()
+		"status", order.Status.String(),
+		"version", order.Version,
+		"line_items", lineItems,
+		"create_time", formatTime(order.CreateTime),
+		"update_time", formatTime(order.UpdateTime),
+		"customer_id", order.CustomerId,
+		"total", total,
+		"labels", labels,
+		"expire_time", formatTime(order.ExpireTime),
+	}, nil
+}
+
+func formatTime(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	return ts.AsTime().Format(time.RFC3339Nano)
+}
+
+// parseTime читает время из хэша; пустое значение - нет времени (заказ без срока истечения).
+func parseTime(value string) (*timestamppb.Timestamp, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return nil, err
+	}
+	return timestamppb.New(t), nil
+}
+
+// requiredTime как parseTime, но время обязательно.
+func requiredTime(value string) (*timestamppb.Timestamp, error) {
+	if value == "" {
+		return nil, errors.New("missing value")
+	}
+	return parseTime(value)
+}
+
+func decodeOrder(values map[string]string) (*test.Order, error) {
+	quantity, err := strconv.ParseInt(values["quantity"], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid quantity: %w", err)
+	}
+
+	version, err := strconv.ParseInt(values["version"], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version: %w", err)
+	}
+
+	var lineItems []*test.LineItem
+	if err := json.Unmarshal([]byte(values["line_items"]), &lineItems); err != nil {
+		return nil, fmt.Errorf("invalid line items: %w", err)
+	}
+
+	var total *money.Money
+	if err := json.Unmarshal([]byte(values["total"]), &total); err != nil {
+		return nil, fmt.Errorf("invalid total: %w", err)
+	}
+
+	var labels map[string]string
+	if raw := values["labels"]; raw != "" {
+		if err := json.Unmarshal([]byte(raw), &labels); err != nil {
+			return nil, fmt.Errorf("invalid labels: %w", err)
+		}
+	}
+
+	createTime, err := requiredTime(values["create_time"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid create_time: %w", err)
+	}
+
+	updateTime, err := requiredTime(values["update_time"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid update_time: %w", err)
+	}
+
+	expireTime, err := parseTime(values["expire_time"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid expire_time: %w", err)
+	}
+
+	return &test.Order{
+		Id:         values["id"],
+		Item:       values["item"],
+		Quantity:   int32(quantity),
+		Status:     test.OrderStatus(test.OrderStatus_value[values["status"]]),
+		LineItems:  lineItems,
+		Version:    version,
+		CreateTime: createTime,
+		UpdateTime: updateTime,
+		CustomerId: values["customer_id"],
+		Total:      total,
+		Labels:     labels,
+		ExpireTime: expireTime,
+	}, nil
+}
+
+func (r *orderRepository) Create(ctx context.Context, order *test.Order) error {
+	key := orderKey(order.Id)
+
+	fields, err := orderHash(order)
+	if err != nil {
+		return err
+	}
+
+	err = r.client.HSet(ctx, key, fields...).Err()
 	if err != nil {
 		return fmt.Errorf("redis create: %w", err)
 	}
 
 	// TTL 10 минут
-	r.client.Expire(ctx, key, 10*time.Minute)
+	r.client.Expire(ctx, key, orderTTL)
 	return nil
 }
 
+func (r *orderRepository) CreateWithKey(ctx context.Context, order *test.Order, key repository.IdempotencyKey) (string, error) {
+	if err := r.Create(ctx, order); err != nil {
+		return "", err
+	}
+	return order.Id, nil
+}
+
 func (r *orderRepository) Get(ctx context.Context, id string) (*test.Order, error) {
-	key := "order:" + id
+	key := orderKey(id)
 
 	values, err := r.client.HGetAll(ctx, key).Result()
 	if err != nil {
@@ -52,48 +192,132 @@ func (r *orderRepository) Get(ctx context.Context, id string) (*test.Order, erro
 		return nil, fmt.Errorf("order not found")
 	}
 
-	quantity, err := strconv.ParseInt(values["quantity"], 10, 32)
-	if err != nil {
-		return nil, fmt.Errorf("invalid quantity: %w", err)
-	}
+	return decodeOrder(values)
+}
 
-	return &test.Order{
-		Id:       values["id"],
-		Item:     values["item"],
-		Quantity: int32(quantity),
-	}, nil
+func (r *orderRepository) Update(ctx context.Context, order *test.Order, fields []string) error {
+	key := orderKey(order.Id)
+	return r.client.Del(ctx, key).Err()
+}
+
+func (r *orderRepository) SetStatus(ctx context.Context, order *test.Order, to test.OrderStatus) error {
+	key := orderKey(order.Id)
+	return r.client.Del(ctx, key).Err()
 }
 
-func (r *orderRepository) Update(ctx context.Context, order *test.Order) error {
-	key := "order:" + order.Id
+func (r *orderRepository) Delete(ctx context.Context, id string, version int64) error {
+	key := orderKey(id)
 	return r.client.Del(ctx, key).Err()
 }
 
-func (r *orderRepository) Delete(ctx context.Context, id string) error {
-	key := "order:" + id
+type cachedPage struct {
+	Orders        []*test.Order `json:"orders"`
+	NextPageToken string        `json:"next_page_token"`
+}
+
+// listGenerationKey - поколение закэшированных страниц. Каждая запись заказов его
+// увеличивает, и страницы прошлых поколений больше не находятся по ключу, а просто
+// доживают свой TTL.
+const listGenerationKey = "orders:list:generation"
+
+// listTTL - сколько живёт закэшированная страница
+const listTTL = 10 * time.Minute
+
+// listKey - ключ закэшированной страницы в поколении generation.
+func listKey(opts repository.ListOptions, generation int64) string {
+	data, _ := json.Marshal(opts)
+	return fmt.Sprintf("orders:list:%d:%x", generation, sha256.Sum256(data))
+}
+
+// ListGeneration возвращает текущее поколение страниц; страницу, прочитанную из базы,
+// нужно сохранять с поколением, взятым до чтения.
+func (r *orderRepository) ListGeneration(ctx context.Context) (int64, error) {
+	generation, err := r.client.Get(ctx, listGenerationKey).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	return generation, err
+}
+
+func (r *orderRepository) Undelete(ctx context.Context, id string) error {
+	key := orderKey(id)
 	return r.client.Del(ctx, key).Err()
 }
 
-func (r *orderRepository) List(ctx context.Context) ([]*test.Order, error) {
+func (r *orderRepository) List(ctx context.Context, opts repository.ListOptions) ([]*test.Order, string, error) {
+
+	generation, err := r.ListGeneration(ctx)
+	if err != nil {
+		return nil, "", fmt.Errorf("list generation: %w", err)
+	}
+
+	cached, err := r.client.Get(ctx, listKey(opts, generation)).Result()
+	if err != nil {
+		return nil, "", fmt.Errorf("no list in cache: %w", err)
+	}
+
+	var page cachedPage
+	if err := json.Unmarshal([]byte(cached), &page); err != nil {
+		return nil, "", fmt.Errorf("unmarshal: %w", err)
+	}
+
+	return page.Orders, page.NextPageToken, nil
+}
+
+// Stream кэш не поддерживает: выгрузки всегда идут из базы.
+func (r *orderRepository) Stream(ctx context.Context, opts repository.ListOptions, send func(*test.Order) error) error {
+	return fmt.Errorf("redis stream: not supported")
+}
+
+// Search кэш не поддерживает: результаты поиска всегда из базы.
+func (r *orderRepository) Search(ctx context.Context, opts repository.SearchOptions) ([]*test.Order, string, error) {
+	return nil, "", fmt.Errorf("redis search: not supported")
+}
+
+// History кэш не поддерживает: история всегда читается из базы.
+func (r *orderRepository) History(ctx context.Context, opts repository.HistoryOptions) ([]*test.OrderHistoryEntry, string, error) {
+	return nil, "", fmt.Errorf("redis history: not supported")
+}
+
+// statsTTL - сколько живёт статистика в кэше. При изменениях заказов она не сбрасывается,
+// так что это и есть максимальное отставание GetOrderStats.
+const statsTTL = time.Minute
 
-	cached, err := r.client.Get(ctx, "orders:list").Result()
+func statsKey(opts repository.StatsOptions) string {
+	data, _ := json.Marshal(opts)
+	return fmt.Sprintf("orders:stats:%x", sha256.Sum256(data))
+}
+
+func (r *orderRepository) Stats(ctx context.Context, opts repository.StatsOptions) (*test.OrderStats, error) {
+	cached, err := r.client.Get(ctx, statsKey(opts)).Result()
 	if err != nil {
-		return nil, fmt.Errorf("no list in cache: %w", err)
+		return nil, fmt.Errorf("no stats in cache: %w", err)
 	}
 
-	var orders []*test.Order
-	if err := json.Unmarshal([]byte(cached), &orders); err != nil {
+	var stats test.OrderStats
+	if err := json.Unmarshal([]byte(cached), &stats); err != nil {
 		return nil, fmt.Errorf("unmarshal: %w", err)
 	}
+	return &stats, nil
+}
 
-	return orders, nil
+func (r *orderRepository) SaveStats(ctx context.Context, opts repository.StatsOptions, stats *test.OrderStats) error {
+	data, err := json.Marshal(stats)
+	if err != nil {
+		return fmt.Errorf("marshal stats: %w", err)
+	}
+	return r.client.Set(ctx, statsKey(opts), data, statsTTL).Err()
 }
 
-func (r *orderRepository) SaveList(ctx context.Context, orders []*test.Order) error {
-	data, err := json.Marshal(orders)
+func (r *orderRepository) SaveList(ctx context.Context, opts repository.ListOptions, generation int64, orders []*test.Order, nextPageToken string) error {
+	data, err := json.Marshal(cachedPage{Orders: orders, NextPageToken: nextPageToken})
 	if err != nil {
 		return fmt.Errorf("marshal orders: %w", err)
 	}
+	return r.client.Set(ctx, listKey(opts, generation), data, listTTL).Err()
+}
 
-	return r.client.Set(ctx, "orders:list", data, 10*time.Minute).Err()
+// InvalidateLists сбрасывает все закэшированные страницы ListOrders и ListCustomerOrders.
+func (r *orderRepository) InvalidateLists(ctx context.Context) error {
+	return r.client.Incr(ctx, listGenerationKey).Err()
 }
@@ -0,0 +1,162 @@
+// Package selector разбирает селекторы меток в стиле Kubernetes
+// (https://kubernetes.io/docs/concepts/overview/working-with-objects/labels/#label-selectors):
+// "channel=web,region in (eu,us),!internal". Требования через запятую объединяются по И.
+// Как селектор превращается в SQL, решает репозиторий.
+package selector
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Operator - вид требования к метке.
+type Operator string
+
+const (
+	OpEquals       Operator = "="
+	OpNotEquals    Operator = "!="
+	OpIn           Operator = "in"
+	OpNotIn        Operator = "notin"
+	OpExists       Operator = "exists"
+	OpDoesNotExist Operator = "!"
+)
+
+// Requirement - одно требование селектора. Values пуст для OpExists и OpDoesNotExist,
+// для OpEquals и OpNotEquals в нём одно значение.
+type Requirement struct {
+	Key    string
+	Op     Operator
+	Values []string
+}
+
+// Parse разбирает селектор; пустая строка - селектор без требований.
+func Parse(s string) ([]Requirement, error) {
+	p := &parser{input: s}
+	p.skipSpaces()
+	if p.done() {
+		return nil, nil
+	}
+
+	var reqs []Requirement
+	for {
+		req, err := p.requirement()
+		if err != nil {
+			return nil, err
+		}
+		reqs = append(reqs, req)
+
+		p.skipSpaces()
+		if p.done() {
+			return reqs, nil
+		}
+		if !p.consume(",") {
+			return nil, p.errorf("expected ',' between requirements")
+		}
+	}
+}
+
+type parser struct {
+	input string
+	pos   int
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("at position %d: %s", p.pos+1, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) skipSpaces() {
+	for !p.done() && (p.input[p.pos] == ' ' || p.input[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+func (p *parser) peek(tok string) bool {
+	return strings.HasPrefix(p.input[p.pos:], tok)
+}
+
+func (p *parser) consume(tok string) bool {
+	if p.peek(tok) {
+		p.pos += len(tok)
+		return true
+	}
+	return false
+}
+
+func isNameChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '-' || c == '_' || c == '.' || c == '/'
+}
+
+// name читает ключ или значение; пустая строка - имени на этой позиции нет.
+func (p *parser) name() string {
+	start := p.pos
+	for !p.done() && isNameChar(p.input[p.pos]) {
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+func (p *parser) requirement() (Requirement, error) {
+	p.skipSpaces()
+	if p.consume("!") {
+		p.skipSpaces()
+		key := p.name()
+		if key == "" {
+			return Requirement{}, p.errorf("expected label key after '!'")
+		}
+		return Requirement{Key: key, Op: OpDoesNotExist}, nil
+	}
+
+	key := p.name()
+	if key == "" {
+		return Requirement{}, p.errorf("expected label key")
+	}
+	p.skipSpaces()
+
+	switch {
+	case p.done() || p.peek(","):
+		return Requirement{Key: key, Op: OpExists}, nil
+	case p.consume("!="):
+		return p.single(key, OpNotEquals)
+	case p.consume("=="), p.consume("="):
+		return p.single(key, OpEquals)
+	}
+
+	word := p.name()
+	switch word {
+	case "in":
+		return p.set(key, OpIn)
+	case "notin":
+		return p.set(key, OpNotIn)
+	}
+	return Requirement{}, p.errorf("expected operator after %q", key)
+}
+
+func (p *parser) single(key string, op Operator) (Requirement, error) {
+	p.skipSpaces()
+	return Requirement{Key: key, Op: op, Values: []string{p.name()}}, nil
+}
+
+func (p *parser) set(key string, op Operator) (Requirement, error) {
+	p.skipSpaces()
+	if !p.consume("(") {
+		return Requirement{}, p.errorf("expected '(' after %s", op)
+	}
+
+	var values []string
+	for {
+		p.skipSpaces()
+		values = append(values, p.name())
+		p.skipSpaces()
+		if p.consume(")") {
+			return Requirement{Key: key, Op: op, Values: values}, nil
+		}
+		if !p.consume(",") {
+			return Requirement{}, p.errorf("expected ',' or ')' in value set")
+		}
+	}
+}
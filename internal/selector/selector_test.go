@@ -0,0 +1,81 @@
+package selector
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []Requirement
+	}{
+		{name: "empty", input: " ", want: nil},
+		{name: "equals", input: "channel=web", want: []Requirement{{Key: "channel", Op: OpEquals, Values: []string{"web"}}}},
+		{name: "double equals", input: "channel == web", want: []Requirement{{Key: "channel", Op: OpEquals, Values: []string{"web"}}}},
+		{name: "not equals", input: "channel!=web", want: []Requirement{{Key: "channel", Op: OpNotEquals, Values: []string{"web"}}}},
+		{name: "empty value", input: "channel=", want: []Requirement{{Key: "channel", Op: OpEquals, Values: []string{""}}}},
+		{name: "exists", input: "internal", want: []Requirement{{Key: "internal", Op: OpExists}}},
+		{name: "does not exist", input: "! internal", want: []Requirement{{Key: "internal", Op: OpDoesNotExist}}},
+		{name: "prefixed key", input: "example.com/team=a_b", want: []Requirement{{Key: "example.com/team", Op: OpEquals, Values: []string{"a_b"}}}},
+		{
+			name:  "in",
+			input: "region in (eu, us)",
+			want:  []Requirement{{Key: "region", Op: OpIn, Values: []string{"eu", "us"}}},
+		},
+		{
+			name:  "notin",
+			input: "region notin (eu)",
+			want:  []Requirement{{Key: "region", Op: OpNotIn, Values: []string{"eu"}}},
+		},
+		{
+			name:  "several requirements",
+			input: "channel=web, region in (eu,us) ,!internal,vip",
+			want: []Requirement{
+				{Key: "channel", Op: OpEquals, Values: []string{"web"}},
+				{Key: "region", Op: OpIn, Values: []string{"eu", "us"}},
+				{Key: "internal", Op: OpDoesNotExist},
+				{Key: "vip", Op: OpExists},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.input, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{name: "no key", input: "=web", wantErr: "at position 1: expected label key"},
+		{name: "bang without key", input: "!", wantErr: "expected label key after '!'"},
+		{name: "unknown operator", input: "region like (eu)", wantErr: `expected operator after "region"`},
+		{name: "set without parentheses", input: "region in eu", wantErr: "expected '(' after in"},
+		{name: "unclosed set", input: "region in (eu", wantErr: "expected ',' or ')'"},
+		{name: "missing comma", input: "a=1 b=2", wantErr: "expected ',' between requirements"},
+		{name: "trailing comma", input: "a=1,", wantErr: "expected label key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.input)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Parse(%q) error = %v, want containing %q", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
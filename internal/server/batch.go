@@ -0,0 +1,132 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"rpc/internal/apierror"
+	"rpc/pkg/api/test"
+)
+
+const maxBatchSize = 1000
+
+// badRequest собирает InvalidArgument с google.rpc.BadRequest, где каждое нарушение
+// указывает на конкретный элемент пакета.
+func badRequest(violations []*errdetails.BadRequest_FieldViolation) error {
+	st := status.New(codes.InvalidArgument, fmt.Sprintf("%d invalid items in batch", len(violations)))
+	if withDetails, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// fieldViolation превращает ошибку проверки заказа в нарушение с путём относительно prefix
+// (пустой prefix - путь от корня запроса).
+func fieldViolation(prefix string, err error) *errdetails.BadRequest_FieldViolation {
+	var fe *fieldError
+	if errors.As(err, &fe) {
+		field := fe.field
+		if prefix != "" {
+			field = prefix + "." + field
+		}
+		return &errdetails.BadRequest_FieldViolation{Field: field, Description: fe.description}
+	}
+	return &errdetails.BadRequest_FieldViolation{Field: prefix, Description: err.Error()}
+}
+
+func checkBatchSize(n int) error {
+	if n > maxBatchSize {
+		return status.Errorf(codes.InvalidArgument, "batch of %d items exceeds the limit of %d", n, maxBatchSize)
+	}
+	return nil
+}
+
+func checkIDs(ids []string) error {
+	if err := checkBatchSize(len(ids)); err != nil {
+		return err
+	}
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	for i, id := range ids {
+		if id == "" {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       fmt.Sprintf("ids[%d]", i),
+				Description: "id must not be empty",
+			})
+		}
+	}
+	if len(violations) > 0 {
+		return badRequest(violations)
+	}
+
+	return nil
+}
+
+// batchError сохраняет детали по элементам и приводит ошибку к ошибке API.
+func batchError(err error, action string) error {
+	return apierror.From(err, action+" orders")
+}
+
+func (s *Serv) BatchCreateOrders(ctx context.Context, req *test.BatchCreateOrdersRequest) (*test.BatchCreateOrdersResponse, error) {
+	if err := checkBatchSize(len(req.Requests)); err != nil {
+		return nil, err
+	}
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	orders := make([]*test.Order, 0, len(req.Requests))
+	for i, r := range req.Requests {
+		if r.RequestId != "" {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       fmt.Sprintf("requests[%d].request_id", i),
+				Description: "idempotency keys are not supported in batch requests",
+			})
+			continue
+		}
+		order, err := s.newOrder(r)
+		if err != nil {
+			violations = append(violations, fieldViolation(fmt.Sprintf("requests[%d]", i), err))
+			continue
+		}
+		orders = append(orders, order)
+	}
+	if len(violations) > 0 {
+		return nil, badRequest(violations)
+	}
+
+	if err := s.repo.BatchCreate(ctx, orders); err != nil {
+		return nil, batchError(err, "create")
+	}
+
+	setEtag(orders...)
+	return &test.BatchCreateOrdersResponse{Orders: orders}, nil
+}
+
+func (s *Serv) BatchGetOrders(ctx context.Context, req *test.BatchGetOrdersRequest) (*test.BatchGetOrdersResponse, error) {
+	if err := checkIDs(req.Ids); err != nil {
+		return nil, err
+	}
+
+	orders, err := s.repo.BatchGet(ctx, req.Ids)
+	if err != nil {
+		return nil, batchError(err, "get")
+	}
+
+	setEtag(orders...)
+	return &test.BatchGetOrdersResponse{Orders: orders}, nil
+}
+
+func (s *Serv) BatchDeleteOrders(ctx context.Context, req *test.BatchDeleteOrdersRequest) (*test.BatchDeleteOrdersResponse, error) {
+	if err := checkIDs(req.Ids); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.BatchDelete(ctx, req.Ids); err != nil {
+		return nil, batchError(err, "delete")
+	}
+
+	return &test.BatchDeleteOrdersResponse{Success: true}, nil
+}
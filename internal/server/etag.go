@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+	"rpc/pkg/api/test"
+)
+
+// ifMatchKey - ключ метаданных, под которым gateway передаёт HTTP-заголовок If-Match.
+const ifMatchKey = "if-match"
+
+func etagOf(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+func setEtag(orders ...*test.Order) {
+	for _, order := range orders {
+		order.Etag = etagOf(order.Version)
+	}
+}
+
+// expectedVersion возвращает версию, которую клиент ожидает увидеть у заказа:
+// из etag в запросе и из If-Match. Если переданы оба, они должны совпадать.
+// 0 означает "без проверки".
+func expectedVersion(ctx context.Context, etag string) (int64, error) {
+	version, err := parseEtag(etag)
+	if err != nil {
+		return 0, err
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(ifMatchKey)
+	if len(values) == 0 {
+		return version, nil
+	}
+	fromHeader, err := parseEtag(values[0])
+	if err != nil {
+		return 0, err
+	}
+	if version == 0 {
+		return fromHeader, nil
+	}
+	if fromHeader != 0 && fromHeader != version {
+		return 0, fmt.Errorf("etag %s does not match If-Match %s", strings.TrimSpace(etag), strings.TrimSpace(values[0]))
+	}
+
+	return version, nil
+}
+
+// parseEtag разбирает "N" или W/"N"; пустое значение и "*" дают 0.
+func parseEtag(etag string) (int64, error) {
+	etag = strings.TrimSpace(etag)
+	if etag == "" || etag == "*" {
+		return 0, nil
+	}
+
+	unquoted, err := strconv.Unquote(strings.TrimPrefix(etag, "W/"))
+	if err != nil {
+		return 0, fmt.Errorf("malformed etag %s", etag)
+	}
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, fmt.Errorf("malformed etag %s", etag)
+	}
+
+	return version, nil
+}
@@ -0,0 +1,123 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"rpc/pkg/api/test"
+)
+
+func TestEtagOf(t *testing.T) {
+	if got := etagOf(42); got != `"42"` {
+		t.Errorf(`etagOf(42) = %s, want "42"`, got)
+	}
+	version, err := expectedVersion(context.Background(), etagOf(42))
+	if err != nil || version != 42 {
+		t.Errorf("expectedVersion(etagOf(42)) = %d, %v, want 42", version, err)
+	}
+}
+
+func TestExpectedVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		etag    string
+		ifMatch []string
+		want    int64
+		wantErr string
+	}{
+		{name: "none"},
+		{name: "field", etag: `"3"`, want: 3},
+		{name: "weak field", etag: ` W/"3" `, want: 3},
+		{name: "wildcard field", etag: "*"},
+		{name: "header", ifMatch: []string{`"4"`}, want: 4},
+		{name: "first header value", ifMatch: []string{`"4"`, `"5"`}, want: 4},
+		{name: "field and matching header", etag: `"3"`, ifMatch: []string{`W/"3"`}, want: 3},
+		{name: "field and wildcard header", etag: `"3"`, ifMatch: []string{"*"}, want: 3},
+		{name: "conflict", etag: `"3"`, ifMatch: []string{`"4"`}, wantErr: `etag "3" does not match If-Match "4"`},
+		{name: "unquoted", etag: "3", wantErr: "malformed etag 3"},
+		{name: "not a number", etag: `"abc"`, wantErr: "malformed etag"},
+		{name: "zero", etag: `"0"`, wantErr: "malformed etag"},
+		{name: "negative", etag: `"-1"`, wantErr: "malformed etag"},
+		{name: "malformed header", ifMatch: []string{"abc"}, wantErr: "malformed etag abc"},
+		{name: "field and malformed header", etag: `"3"`, ifMatch: []string{"abc"}, wantErr: "malformed etag abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.ifMatch != nil {
+				ctx = metadata.NewIncomingContext(ctx, metadata.MD{ifMatchKey: tt.ifMatch})
+			}
+
+			got, err := expectedVersion(ctx, tt.etag)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expectedVersion() = %d, %v, want error containing %q", got, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expectedVersion() error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expectedVersion() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateOrderEtag(t *testing.T) {
+	current := &test.Order{
+		Id:        "order-1",
+		Item:      "Laptop",
+		Quantity:  2,
+		LineItems: []*test.LineItem{{Item: "Laptop", Quantity: 2}},
+		Version:   3,
+	}
+
+	tests := []struct {
+		name     string
+		etag     string
+		ifMatch  string
+		wantCode codes.Code
+	}{
+		{name: "current etag", etag: `"3"`},
+		{name: "current If-Match", ifMatch: `"3"`},
+		{name: "stale etag", etag: `"2"`, wantCode: codes.Aborted},
+		{name: "stale If-Match", ifMatch: `"2"`, wantCode: codes.Aborted},
+		{name: "conflicting etags", etag: `"3"`, ifMatch: `"2"`, wantCode: codes.InvalidArgument},
+		{name: "malformed etag", etag: "3", wantCode: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeRepository(current)
+			ctx := context.Background()
+			if tt.ifMatch != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(ifMatchKey, tt.ifMatch))
+			}
+
+			resp, err := newTestServer(repo).UpdateOrder(ctx, &test.UpdateOrderRequest{
+				Order:      &test.Order{Id: "order-1", Quantity: 5, Etag: tt.etag},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"quantity"}},
+			})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("UpdateOrder() code = %s, want %s (%v)", code, tt.wantCode, err)
+			}
+			if tt.wantCode != codes.OK {
+				if got := repo.orders["order-1"].Quantity; got != 2 {
+					t.Errorf("stored quantity = %d, want unchanged 2", got)
+				}
+				return
+			}
+			if resp.Order.Etag != `"4"` {
+				t.Errorf("UpdateOrder() etag = %s, want \"4\"", resp.Order.Etag)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"rpc/internal/repository"
+	"rpc/pkg/api/test"
+)
+
+const (
+	// idempotencyKeyHeader - ключ метаданных, под которым gateway передаёт Idempotency-Key.
+	idempotencyKeyHeader = "idempotency-key"
+	idempotencyTTL       = 24 * time.Hour
+	maxIdempotencyKeyLen = 255
+)
+
+// idempotencyKey собирает ключ идемпотентности из request_id или заголовка Idempotency-Key;
+// если переданы оба, они должны совпадать. ok == false, если клиент ключ не передал.
+func idempotencyKey(ctx context.Context, req *test.CreateOrderRequest) (key repository.IdempotencyKey, ok bool, err error) {
+	value := req.RequestId
+	if md, found := metadata.FromIncomingContext(ctx); found {
+		if values := md.Get(idempotencyKeyHeader); len(values) > 0 && values[0] != "" {
+			if value != "" && value != values[0] {
+				return key, false, fmt.Errorf("request_id %q does not match Idempotency-Key %q", value, values[0])
+			}
+			value = values[0]
+		}
+	}
+	if value == "" {
+		return key, false, nil
+	}
+	if len(value) > maxIdempotencyKeyLen {
+		return key, false, fmt.Errorf("request_id is longer than %d characters", maxIdempotencyKeyLen)
+	}
+	for i := 0; i < len(value); i++ {
+		if value[i] < '!' || value[i] > '~' {
+			return key, false, fmt.Errorf("request_id must contain only printable ASCII characters without spaces")
+		}
+	}
+
+	// отпечаток считается без самого ключа, чтобы request_id и заголовок давали одно и то же
+	body := proto.Clone(req).(*test.CreateOrderRequest)
+	body.RequestId = ""
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(body)
+	if err != nil {
+		return key, false, fmt.Errorf("marshal request: %w", err)
+	}
+	sum := sha256.Sum256(data)
+
+	return repository.IdempotencyKey{
+		Key:         value,
+		RequestHash: hex.EncodeToString(sum[:]),
+		TTL:         idempotencyTTL,
+	}, true, nil
+}
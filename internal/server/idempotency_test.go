@@ -0,0 +1,113 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"rpc/pkg/api/test"
+)
+
+func TestIdempotencyKey(t *testing.T) {
+	tests := []struct {
+		name      string
+		requestID string
+		header    []string
+		wantKey   string
+		wantErr   string
+	}{
+		{name: "none"},
+		{name: "field", requestID: "key-1", wantKey: "key-1"},
+		{name: "header", header: []string{"key-2"}, wantKey: "key-2"},
+		{name: "field and same header", requestID: "key-1", header: []string{"key-1"}, wantKey: "key-1"},
+		{name: "field and empty header", requestID: "key-1", header: []string{""}, wantKey: "key-1"},
+		{name: "conflict", requestID: "key-1", header: []string{"key-2"}, wantErr: `request_id "key-1" does not match Idempotency-Key "key-2"`},
+		{name: "longest key", requestID: strings.Repeat("k", maxIdempotencyKeyLen), wantKey: strings.Repeat("k", maxIdempotencyKeyLen)},
+		{name: "too long", requestID: strings.Repeat("k", maxIdempotencyKeyLen+1), wantErr: "longer than 255 characters"},
+		{name: "too long header", header: []string{strings.Repeat("k", maxIdempotencyKeyLen+1)}, wantErr: "longer than 255 characters"},
+		{name: "space", requestID: "key 1", wantErr: "printable ASCII"},
+		{name: "control character", header: []string{"key\t1"}, wantErr: "printable ASCII"},
+		{name: "not ascii", requestID: "ключ", wantErr: "printable ASCII"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.header != nil {
+				ctx = metadata.NewIncomingContext(ctx, metadata.MD{idempotencyKeyHeader: tt.header})
+			}
+
+			key, ok, err := idempotencyKey(ctx, &test.CreateOrderRequest{Item: "Laptop", Quantity: 1, RequestId: tt.requestID})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("idempotencyKey() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("idempotencyKey() error: %v", err)
+			}
+			if ok != (tt.wantKey != "") || key.Key != tt.wantKey {
+				t.Errorf("idempotencyKey() = %q, %v, want %q", key.Key, ok, tt.wantKey)
+			}
+		})
+	}
+}
+
+// Отпечаток не зависит от того, где передан ключ, но зависит от тела запроса.
+func TestIdempotencyKeyHash(t *testing.T) {
+	ctx := context.Background()
+	hash := func(ctx context.Context, req *test.CreateOrderRequest) string {
+		t.Helper()
+		key, _, err := idempotencyKey(ctx, req)
+		if err != nil {
+			t.Fatalf("idempotencyKey() error: %v", err)
+		}
+		return key.RequestHash
+	}
+
+	fromField := hash(ctx, &test.CreateOrderRequest{Item: "Laptop", Quantity: 1, RequestId: "key-1"})
+	fromHeader := hash(metadata.NewIncomingContext(ctx, metadata.Pairs(idempotencyKeyHeader, "key-1")),
+		&test.CreateOrderRequest{Item: "Laptop", Quantity: 1})
+	other := hash(ctx, &test.CreateOrderRequest{Item: "Laptop", Quantity: 2, RequestId: "key-1"})
+
+	if fromField != fromHeader {
+		t.Errorf("hash from field %s != hash from header %s", fromField, fromHeader)
+	}
+	if fromField == other {
+		t.Errorf("different requests have the same hash %s", other)
+	}
+}
+
+func TestCreateOrderIdempotent(t *testing.T) {
+	repo := newFakeRepository()
+	s := newTestServer(repo)
+	ctx := context.Background()
+
+	first, err := s.CreateOrder(ctx, &test.CreateOrderRequest{Item: "Laptop", Quantity: 1, RequestId: "key-1"})
+	if err != nil {
+		t.Fatalf("CreateOrder() error: %v", err)
+	}
+
+	retry, err := s.CreateOrder(metadata.NewIncomingContext(ctx, metadata.Pairs(idempotencyKeyHeader, "key-1")),
+		&test.CreateOrderRequest{Item: "Laptop", Quantity: 1})
+	if err != nil {
+		t.Fatalf("CreateOrder() retry error: %v", err)
+	}
+	if retry.Id != first.Id || len(repo.orders) != 1 {
+		t.Errorf("retry created order %s (%d orders), want %s", retry.Id, len(repo.orders), first.Id)
+	}
+
+	_, err = s.CreateOrder(ctx, &test.CreateOrderRequest{Item: "Laptop", Quantity: 2, RequestId: "key-1"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("CreateOrder() with a reused key error = %v, want InvalidArgument", err)
+	}
+
+	_, err = s.CreateOrder(ctx, &test.CreateOrderRequest{Item: "Laptop", Quantity: 1, RequestId: "key 2"})
+	if status.Code(err) != codes.InvalidArgument || len(repo.orders) != 1 {
+		t.Errorf("CreateOrder() with a malformed key error = %v (%d orders), want InvalidArgument", err, len(repo.orders))
+	}
+}
@@ -0,0 +1,121 @@
+package server
+
+import (
+	"io"
+	"strconv"
+
+	"rpc/internal/apierror"
+	"rpc/internal/validate"
+	"rpc/pkg/api/test"
+)
+
+const (
+	// importBatchSize - сколько заказов копится перед записью одним COPY.
+	importBatchSize = 1000
+	// maxImportFailures - сколько ошибок по записям вернуть в ответе, остальные только считаются.
+	maxImportFailures = 1000
+)
+
+// validateImport проверяет запись импорта по правилам из api/order.proto и собирает
+// заказ; нарушения возвращаются по полям записи, ошибка - только если проверить не удалось.
+func (s *Serv) validateImport(req *test.ImportOrdersRequest) (*test.Order, []*test.ImportFailure, error) {
+	violations, err := validate.Message(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(violations) > 0 {
+		failures := make([]*test.ImportFailure, 0, len(violations))
+		for _, v := range violations {
+			failures = append(failures, &test.ImportFailure{Field: v.Field, Description: v.Description})
+		}
+		return nil, failures, nil
+	}
+
+	order, err := s.newOrder(&test.CreateOrderRequest{
+		Item:       req.Item,
+		Quantity:   req.Quantity,
+		LineItems:  req.LineItems,
+		CustomerId: req.CustomerId,
+		OrderId:    req.OrderId,
+		Labels:     req.Labels,
+	})
+	if err != nil {
+		v := fieldViolation("", err)
+		return nil, []*test.ImportFailure{{Field: v.Field, Description: v.Description}}, nil
+	}
+	// импорт переносит уже существующие заказы вместе со статусом, срок
+	// подтверждения им не назначается
+	if req.Status != test.OrderStatus_ORDER_STATUS_UNSPECIFIED {
+		order.Status = req.Status
+	}
+	order.ExpireTime = nil
+	return order, nil, nil
+}
+
+// ImportOrders принимает поток заказов и пишет корректные пачками по importBatchSize.
+// Некорректные записи пропускаются и попадают в сводку. Уже записанные пачки
+// при ошибке записи не откатываются: одна транзакция на весь поток держала бы
+// блокировки остатков до конца импорта. Сколько заказов записано, ошибка говорит
+// в сообщении и в metadata "imported".
+func (s *Serv) ImportOrders(stream test.OrderService_ImportOrdersServer) error {
+	ctx := stream.Context()
+
+	summary := &test.ImportOrdersResponse{}
+	batch := make([]*test.Order, 0, importBatchSize)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := s.repo.Import(ctx, batch); err != nil {
+			imported := strconv.FormatInt(summary.Imported, 10)
+			return apierror.Prefix(apierror.From(err, "import orders", "imported", imported),
+				imported+" orders imported before the error")
+		}
+		summary.Imported += int64(len(batch))
+		batch = batch[:0]
+		return nil
+	}
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		index := summary.Received
+		summary.Received++
+
+		order, failures, err := s.validateImport(req)
+		if err != nil {
+			return apierror.From(err, "validate imported order")
+		}
+		if len(failures) > 0 {
+			summary.Failed++
+			for _, failure := range failures {
+				if len(summary.Failures) == maxImportFailures {
+					break
+				}
+				failure.Index = index
+				summary.Failures = append(summary.Failures, failure)
+			}
+			continue
+		}
+
+		batch = append(batch, order)
+		if len(batch) == importBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return err
+	}
+
+	return stream.SendAndClose(summary)
+}
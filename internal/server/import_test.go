@@ -0,0 +1,133 @@
+package server
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"rpc/internal/repository"
+	"rpc/pkg/api/test"
+)
+
+// importRepository запоминает записанные пачки; пачка с номером failOn (с единицы)
+// завершается ошибкой err.
+type importRepository struct {
+	repository.OrderRepository
+	imported []*test.Order
+	batches  int
+	failOn   int
+	err      error
+}
+
+func (r *importRepository) Import(ctx context.Context, orders []*test.Order) error {
+	r.batches++
+	if r.batches == r.failOn {
+		return r.err
+	}
+	r.imported = append(r.imported, orders...)
+	return nil
+}
+
+// importStream отдаёт записи из requests и запоминает сводку.
+type importStream struct {
+	grpc.ServerStream
+	requests []*test.ImportOrdersRequest
+	summary  *test.ImportOrdersResponse
+}
+
+func (s *importStream) Context() context.Context { return context.Background() }
+
+func (s *importStream) Recv() (*test.ImportOrdersRequest, error) {
+	if len(s.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := s.requests[0]
+	s.requests = s.requests[1:]
+	return req, nil
+}
+
+func (s *importStream) SendAndClose(summary *test.ImportOrdersResponse) error {
+	s.summary = summary
+	return nil
+}
+
+func TestImportOrders(t *testing.T) {
+	repo := &importRepository{}
+	s := newTestServer(repo)
+	s.pendingTTL = time.Hour
+	stream := &importStream{requests: []*test.ImportOrdersRequest{
+		{Item: "Laptop", Quantity: 1},
+		{Item: "Laptop"},
+		{Item: "Tablet", Quantity: 2, Status: test.OrderStatus_ORDER_STATUS_SHIPPED},
+		{Item: "Phone", Quantity: 1, Status: test.OrderStatus(42)},
+	}}
+
+	if err := s.ImportOrders(stream); err != nil {
+		t.Fatalf("ImportOrders() error: %v", err)
+	}
+
+	summary := stream.summary
+	if summary.Received != 4 || summary.Imported != 2 || summary.Failed != 2 {
+		t.Errorf("summary = %d received, %d imported, %d failed, want 4, 2, 2", summary.Received, summary.Imported, summary.Failed)
+	}
+	if len(summary.Failures) != 2 || summary.Failures[0].Index != 1 || summary.Failures[0].Field != "quantity" ||
+		summary.Failures[1].Index != 3 || summary.Failures[1].Field != "status" {
+		t.Errorf("failures = %v, want quantity of record 1 and status of record 3", summary.Failures)
+	}
+
+	wantStatus := []test.OrderStatus{test.OrderStatus_ORDER_STATUS_PENDING, test.OrderStatus_ORDER_STATUS_SHIPPED}
+	if len(repo.imported) != len(wantStatus) {
+		t.Fatalf("imported %d orders, want %d", len(repo.imported), len(wantStatus))
+	}
+	for i, order := range repo.imported {
+		if order.Status != wantStatus[i] {
+			t.Errorf("order %d status = %s, want %s", i, order.Status, wantStatus[i])
+		}
+		if order.ExpireTime != nil {
+			t.Errorf("order %d expire_time = %v, want unset", i, order.ExpireTime)
+		}
+	}
+}
+
+// Пачки до упавшей остаются записанными, и ошибка говорит, сколько в них заказов.
+func TestImportOrdersFailedBatch(t *testing.T) {
+	repo := &importRepository{
+		failOn: 2,
+		err:    repository.InsufficientStock([]repository.StockShortage{{Item: "Laptop", Requested: 5, Available: 1}}),
+	}
+	stream := &importStream{}
+	for range importBatchSize + 10 {
+		stream.requests = append(stream.requests, &test.ImportOrdersRequest{Item: "Laptop", Quantity: 1})
+	}
+
+	err := newTestServer(repo).ImportOrders(stream)
+
+	st := status.Convert(err)
+	if st.Code() != codes.ResourceExhausted {
+		t.Fatalf("code = %s, want ResourceExhausted", st.Code())
+	}
+	if want := "1000 orders imported before the error: insufficient stock: Laptop"; st.Message() != want {
+		t.Errorf("message = %q, want %q", st.Message(), want)
+	}
+	var imported string
+	var quota bool
+	for _, d := range st.Details() {
+		switch d := d.(type) {
+		case *errdetails.ErrorInfo:
+			imported = d.Metadata["imported"]
+		case *errdetails.QuotaFailure:
+			quota = true
+		}
+	}
+	if imported != "1000" || !quota {
+		t.Errorf("details = %v, want imported=1000 and QuotaFailure", st.Details())
+	}
+	if len(repo.imported) != importBatchSize || stream.summary != nil {
+		t.Errorf("imported %d orders, summary %v; want %d and no summary", len(repo.imported), stream.summary, importBatchSize)
+	}
+}
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"context"
+
+	"rpc/internal/apierror"
+	"rpc/internal/repository"
+	"rpc/pkg/api/test"
+)
+
+// InventoryServ отдаёт и меняет остатки позиций. Резервы под заказы ставит Serv
+// через OrderRepository.
+type InventoryServ struct {
+	test.UnimplementedInventoryServiceServer
+	repo repository.InventoryRepository
+}
+
+func NewInventoryServer(repo repository.InventoryRepository) *InventoryServ {
+	return &InventoryServ{repo: repo}
+}
+
+func (s *InventoryServ) GetStock(ctx context.Context, req *test.GetStockRequest) (*test.GetStockResponse, error) {
+	stock, err := s.repo.GetStock(ctx, req.Item)
+	if err != nil {
+		return nil, apierror.From(err, "get stock", "item", req.Item)
+	}
+	return &test.GetStockResponse{Stock: stock}, nil
+}
+
+func (s *InventoryServ) ListStock(ctx context.Context, req *test.ListStockRequest) (*test.ListStockResponse, error) {
+	stocks, next, err := s.repo.ListStock(ctx, repository.StockListOptions{
+		PageSize:  pageSize(req.PageSize),
+		PageToken: req.PageToken,
+	})
+	if err != nil {
+		return nil, apierror.From(err, "list stock")
+	}
+	return &test.ListStockResponse{
+		Stocks:        stocks,
+		NextPageToken: next,
+	}, nil
+}
+
+func (s *InventoryServ) RestockItem(ctx context.Context, req *test.RestockItemRequest) (*test.RestockItemResponse, error) {
+	stock, err := s.repo.Restock(ctx, req.Item, req.Quantity)
+	if err != nil {
+		return nil, apierror.From(err, "restock item", "item", req.Item)
+	}
+	return &test.RestockItemResponse{Stock: stock}, nil
+}
+
+func (s *InventoryServ) AdjustStock(ctx context.Context, req *test.AdjustStockRequest) (*test.AdjustStockResponse, error) {
+	stock, err := s.repo.AdjustStock(ctx, req.Item, req.Delta)
+	if err != nil {
+		return nil, apierror.From(err, "adjust stock", "item", req.Item)
+	}
+	return &test.AdjustStockResponse{Stock: stock}, nil
+}
@@ -0,0 +1,30 @@
+package server
+
+import (
+	"fmt"
+	"math"
+
+	"rpc/pkg/api/test"
+)
+
+// summarize приводит item/quantity и line_items заказа к согласованному виду.
+// Заказ без позиций получает одну позицию из item/quantity (так пишут старые клиенты),
+// а у заказа с позициями item и quantity становятся кратким итогом:
+// первый товар и общее количество. Общее количество должно уместиться в quantity.
+func summarize(order *test.Order) error {
+	if len(order.LineItems) == 0 {
+		order.LineItems = []*test.LineItem{{Item: order.Item, Quantity: order.Quantity}}
+		return nil
+	}
+
+	var total int64
+	for _, line := range order.LineItems {
+		total += int64(line.Quantity)
+	}
+	if total > math.MaxInt32 {
+		return &fieldError{field: "line_items", description: fmt.Sprintf("total quantity must not exceed %d", math.MaxInt32)}
+	}
+	order.Item = order.LineItems[0].Item
+	order.Quantity = int32(total)
+	return nil
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"fmt"
+	"math"
+
+	"rpc/internal/currency"
+	"rpc/pkg/api/test"
+)
+
+// fieldError - ошибка в конкретном поле запроса; field относителен заказа.
+type fieldError struct {
+	field       string
+	description string
+}
+
+func (e *fieldError) Error() string {
+	return e.field + ": " + e.description
+}
+
+// price проверяет цены позиций и считает order.Total. Цены либо есть у всех позиций,
+// либо ни у одной; валюта у всех позиций одна.
+func price(order *test.Order) error {
+	order.Total = nil
+
+	var code string
+	var total int64
+	priced := 0
+	for i, line := range order.LineItems {
+		if line.UnitPrice == nil {
+			continue
+		}
+		priced++
+		field := fmt.Sprintf("line_items[%d].unit_price", i)
+
+		minor, err := currency.ToMinor(line.UnitPrice)
+		if err != nil {
+			return &fieldError{field: field, description: err.Error()}
+		}
+		if minor < 0 {
+			return &fieldError{field: field, description: "price must not be negative"}
+		}
+
+		if code == "" {
+			code = line.UnitPrice.CurrencyCode
+		} else if line.UnitPrice.CurrencyCode != code {
+			return &fieldError{
+				field:       field,
+				description: fmt.Sprintf("currency %s differs from %s used by other lines", line.UnitPrice.CurrencyCode, code),
+			}
+		}
+
+		quantity := int64(line.Quantity)
+		if quantity < 0 {
+			return &fieldError{field: fmt.Sprintf("line_items[%d].quantity", i), description: "quantity must not be negative"}
+		}
+		if minor != 0 && quantity > math.MaxInt64/minor || total > math.MaxInt64-minor*quantity {
+			return &fieldError{field: field, description: "order total is too large"}
+		}
+		total += minor * quantity
+	}
+
+	if priced == 0 {
+		return nil
+	}
+	if priced != len(order.LineItems) {
+		return &fieldError{field: "line_items", description: "either every line item or none must have a unit_price"}
+	}
+
+	order.Total = currency.FromMinor(code, total)
+	return nil
+}
@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/type/money"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"rpc/pkg/api/test"
+)
+
+func TestPrice(t *testing.T) {
+	usd := func(units int64, nanos int32) *money.Money {
+		return &money.Money{CurrencyCode: "USD", Units: units, Nanos: nanos}
+	}
+
+	tests := []struct {
+		name    string
+		lines   []*test.LineItem
+		want    *money.Money
+		wantErr string
+	}{
+		{name: "no prices", lines: []*test.LineItem{{Item: "Laptop", Quantity: 2}}},
+		{
+			name: "sum in minor units",
+			lines: []*test.LineItem{
+				{Item: "Laptop", Quantity: 3, UnitPrice: usd(0, 100_000_000)},
+				{Item: "Mouse", Quantity: 1, UnitPrice: usd(19, 990_000_000)},
+			},
+			want: usd(20, 290_000_000),
+		},
+		{name: "free line", lines: []*test.LineItem{{Item: "Sticker", Quantity: 5, UnitPrice: usd(0, 0)}}, want: usd(0, 0)},
+		{
+			name:    "some lines without price",
+			lines:   []*test.LineItem{{Item: "Laptop", Quantity: 1, UnitPrice: usd(1, 0)}, {Item: "Mouse", Quantity: 1}},
+			wantErr: "line_items: either every line item or none",
+		},
+		{
+			name: "mixed currencies",
+			lines: []*test.LineItem{
+				{Item: "Laptop", Quantity: 1, UnitPrice: usd(1, 0)},
+				{Item: "Mouse", Quantity: 1, UnitPrice: &money.Money{CurrencyCode: "EUR", Units: 1}},
+			},
+			wantErr: "line_items[1].unit_price: currency EUR differs from USD",
+		},
+		{name: "negative price", lines: []*test.LineItem{{Item: "Laptop", Quantity: 1, UnitPrice: usd(-1, 0)}}, wantErr: "must not be negative"},
+		{name: "fraction of a cent", lines: []*test.LineItem{{Item: "Laptop", Quantity: 1, UnitPrice: usd(0, 5_000_000)}}, wantErr: "line_items[0].unit_price: "},
+		{name: "total overflows", lines: []*test.LineItem{{Item: "Laptop", Quantity: 2, UnitPrice: usd(math.MaxInt64/100, 0)}}, wantErr: "order total is too large"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := &test.Order{LineItems: tt.lines, Total: usd(1, 0)}
+			err := price(order)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("price() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("price() error: %v", err)
+			}
+			if !proto.Equal(order.Total, tt.want) {
+				t.Errorf("price() total = %v, want %v", order.Total, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateOrderTotal(t *testing.T) {
+	repo := newFakeRepository()
+	s := newTestServer(repo)
+
+	resp, err := s.CreateOrder(context.Background(), &test.CreateOrderRequest{LineItems: []*test.LineItem{
+		{Item: "Laptop", Quantity: 2, UnitPrice: &money.Money{CurrencyCode: "JPY", Units: 1500}},
+		{Item: "Mouse", Quantity: 1, UnitPrice: &money.Money{CurrencyCode: "JPY", Units: 200}},
+	}})
+	if err != nil {
+		t.Fatalf("CreateOrder() error: %v", err)
+	}
+	want := &money.Money{CurrencyCode: "JPY", Units: 3200}
+	if got := repo.orders[resp.Id].GetTotal(); !proto.Equal(got, want) {
+		t.Errorf("stored total = %v, want %v", got, want)
+	}
+
+	_, err = s.CreateOrder(context.Background(), &test.CreateOrderRequest{LineItems: []*test.LineItem{
+		{Item: "Laptop", Quantity: 1, UnitPrice: &money.Money{CurrencyCode: "JPY", Units: 1, Nanos: 500_000_000}},
+	}})
+	if status.Code(err) != codes.InvalidArgument || len(repo.orders) != 1 {
+		t.Errorf("CreateOrder() with a fraction of a yen error = %v (%d orders), want InvalidArgument", err, len(repo.orders))
+	}
+}
@@ -2,45 +2,106 @@ package server
 
 import (
 	"context"
-	"github.com/google/uuid"
+	"fmt"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"rpc/internal/apierror"
+	"rpc/internal/idgen"
 	"rpc/internal/repository"
+	"rpc/internal/validate"
+	"rpc/internal/watch"
 	"rpc/pkg/api/test"
+	"time"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 1000
 )
 
 type Serv struct {
 	test.UnimplementedOrderServiceServer
 	repo repository.OrderRepository
+	hub  *watch.Hub
+	ids  idgen.IDGenerator
+	// pendingTTL - сколько новый заказ может ждать подтверждения; 0 - не истекает
+	pendingTTL time.Duration
 }
 
-func NewServer(repo repository.OrderRepository) *Serv {
+func NewServer(repo repository.OrderRepository, hub *watch.Hub, ids idgen.IDGenerator, pendingTTL time.Duration) *Serv {
 	return &Serv{
-		repo: repo,
+		repo:       repo,
+		hub:        hub,
+		ids:        ids,
+		pendingTTL: pendingTTL,
 	}
 }
 
-func (s *Serv) idgen() string {
-	return uuid.New().String()
+// newOrder собирает новый заказ из запроса на создание и считает его итог.
+func (s *Serv) newOrder(req *test.CreateOrderRequest) (*test.Order, error) {
+	// это правило связывает несколько полей, поэтому его нет в аннотациях api/order.proto
+	if len(req.LineItems) == 0 {
+		if req.Item == "" {
+			return nil, &fieldError{field: "item", description: "value is required when line_items is empty"}
+		}
+		if req.Quantity <= 0 {
+			return nil, &fieldError{field: "quantity", description: "value must be greater than 0 when line_items is empty"}
+		}
+	}
+
+	id, err := s.ids.NewID(req.OrderId)
+	if err != nil {
+		return nil, &fieldError{field: "order_id", description: err.Error()}
+	}
+
+	order := &test.Order{
+		Id:         id,
+		Item:       req.Item,
+		Quantity:   req.Quantity,
+		Status:     test.OrderStatus_ORDER_STATUS_PENDING,
+		LineItems:  req.LineItems,
+		Version:    1,
+		CustomerId: req.CustomerId,
+		Labels:     req.Labels,
+	}
+	if s.pendingTTL > 0 {
+		order.ExpireTime = timestamppb.New(time.Now().Add(s.pendingTTL))
+	}
+	if err := summarize(order); err != nil {
+		return nil, err
+	}
+	if err := price(order); err != nil {
+		return nil, err
+	}
+	return order, nil
 }
 
 func (s *Serv) CreateOrder(ctx context.Context, req *test.CreateOrderRequest) (*test.CreateOrderResponse, error) {
 
-	id := s.idgen()
+	order, err := s.newOrder(req)
+	if err != nil {
+		return nil, validate.Error(fieldViolation("", err))
+	}
+	id := order.Id
 
-	order := &test.Order{
-		Id:       id,
-		Item:     req.Item,
-		Quantity: req.Quantity,
+	key, idempotent, err := idempotencyKey(ctx, req)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 
-	err := s.repo.Create(ctx, order)
+	if idempotent {
+		// при повторе с тем же ключом вернётся id заказа из первого запроса
+		id, err = s.repo.CreateWithKey(ctx, order, key)
+	} else {
+		err = s.repo.Create(ctx, order)
+	}
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create order: %v", err)
+		return nil, apierror.From(err, "create order")
 	}
 
 	return &test.CreateOrderResponse{
-		Id: order.Id,
+		Id: id,
 	}, nil
 }
 
@@ -49,31 +110,76 @@ func (s *Serv) GetOrder(ctx context.Context, req *test.GetOrderRequest) (*test.G
 	order, err := s.repo.Get(ctx, req.Id)
 
 	if err != nil {
-		if status.Code(err) == codes.NotFound {
-			return nil, status.Errorf(codes.NotFound, "order with id %s not found", req.Id)
-		}
-		return nil, status.Errorf(codes.Internal, "failed to get order: %v", err)
+		return nil, apierror.From(err, "get order", "order_id", req.Id)
 	}
+	setEtag(order)
 	return &test.GetOrderResponse{
 		Order: order,
 	}, nil
 
 }
 
+// GetOrderHistory отдаёт историю изменений заказа от старых записей к новым.
+func (s *Serv) GetOrderHistory(ctx context.Context, req *test.GetOrderHistoryRequest) (*test.GetOrderHistoryResponse, error) {
+	entries, next, err := s.repo.History(ctx, repository.HistoryOptions{
+		OrderID:   req.Id,
+		PageSize:  pageSize(req.PageSize),
+		PageToken: req.PageToken,
+	})
+	if err != nil {
+		return nil, apierror.From(err, "get order history", "order_id", req.Id)
+	}
+	for _, entry := range entries {
+		for _, order := range []*test.Order{entry.OldOrder, entry.NewOrder} {
+			if order != nil {
+				setEtag(order)
+			}
+		}
+	}
+	return &test.GetOrderHistoryResponse{
+		Entries:       entries,
+		NextPageToken: next,
+	}, nil
+}
+
 func (s *Serv) UpdateOrder(ctx context.Context, req *test.UpdateOrderRequest) (*test.UpdateOrderResponse, error) {
-	order := &test.Order{
-		Id:       req.Id,
-		Item:     req.Item,
-		Quantity: req.Quantity,
+	if req.Order == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "order is required")
 	}
-	err := s.repo.Update(ctx, order)
+
+	paths, err := resolveMask(req.UpdateMask, req.Order)
 	if err != nil {
-		if status.Code(err) == codes.NotFound {
-			return nil, status.Errorf(codes.NotFound, "order with id %s not found", req.Id)
-		}
-		return nil, status.Errorf(codes.Internal, "failed to update order: %v", err)
+		return nil, status.Errorf(codes.InvalidArgument, "invalid update_mask: %v", err)
 	}
 
+	expected, err := expectedVersion(ctx, req.Order.Etag)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	current, err := s.repo.Get(ctx, req.Order.Id)
+	if err != nil {
+		return nil, apierror.From(err, "get order", "order_id", req.Order.Id)
+	}
+	if expected > 0 && expected != current.Version {
+		return nil, apierror.New(codes.Aborted, apierror.ReasonConcurrentModification,
+			fmt.Sprintf("order %s was modified concurrently, etag %s is stale", current.Id, etagOf(expected)),
+			"order_id", current.Id, "etag", etagOf(current.Version))
+	}
+
+	// merged несёт версию current, так что запись не пройдёт, если заказ поменяли
+	// между чтением и обновлением
+	order, fields, err := applyMask(current, req.Order, paths)
+	if err != nil {
+		return nil, validate.Error(fieldViolation("order", err))
+	}
+
+	err = s.repo.Update(ctx, order, fields)
+	if err != nil {
+		return nil, apierror.From(err, "update order", "order_id", order.Id)
+	}
+	setEtag(order)
+
 	return &test.UpdateOrderResponse{
 		Order: order,
 	}, nil
@@ -81,23 +187,133 @@ func (s *Serv) UpdateOrder(ctx context.Context, req *test.UpdateOrderRequest) (*
 
 func (s *Serv) DeleteOrder(ctx context.Context, req *test.DeleteOrderRequest) (*test.DeleteOrderResponse, error) {
 
-	err := s.repo.Delete(ctx, req.Id)
+	version, err := expectedVersion(ctx, req.Etag)
 	if err != nil {
-		if status.Code(err) == codes.NotFound {
-			return nil, status.Errorf(codes.NotFound, "order with id %s not found", req.Id)
-		}
-		return nil, status.Errorf(codes.Internal, "failed to delete order: %v", err)
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	err = s.repo.Delete(ctx, req.Id, version)
+	if err != nil {
+		return nil, apierror.From(err, "delete order", "order_id", req.Id)
 	}
 	return &test.DeleteOrderResponse{Success: true}, nil
 }
 
+func (s *Serv) UndeleteOrder(ctx context.Context, req *test.UndeleteOrderRequest) (*test.UndeleteOrderResponse, error) {
+
+	err := s.repo.Undelete(ctx, req.Id)
+	if err != nil {
+		return nil, apierror.From(err, "undelete order", "order_id", req.Id)
+	}
+
+	order, err := s.repo.Get(ctx, req.Id)
+	if err != nil {
+		return nil, apierror.From(err, "get order", "order_id", req.Id)
+	}
+	setEtag(order)
+	return &test.UndeleteOrderResponse{Order: order}, nil
+}
+
 func (s *Serv) ListOrders(ctx context.Context, req *test.ListOrdersRequest) (*test.ListOrdersResponse, error) {
 
-	orders, err := s.repo.List(ctx)
+	orders, next, err := s.list(ctx, req.PageSize, repository.ListOptions{
+		PageToken:     req.PageToken,
+		Filter:        req.Filter,
+		OrderBy:       req.OrderBy,
+		ShowDeleted:   req.ShowDeleted,
+		LabelSelector: req.LabelSelector,
+	})
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to list orders: %v", err)
+		return nil, err
 	}
 	return &test.ListOrdersResponse{
-		Orders: orders,
+		Orders:        orders,
+		NextPageToken: next,
+	}, nil
+}
+
+func (s *Serv) ListCustomerOrders(ctx context.Context, req *test.ListCustomerOrdersRequest) (*test.ListCustomerOrdersResponse, error) {
+	if req.CustomerId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "customer_id is required")
+	}
+
+	orders, next, err := s.list(ctx, req.PageSize, repository.ListOptions{
+		PageToken:     req.PageToken,
+		Filter:        req.Filter,
+		OrderBy:       req.OrderBy,
+		ShowDeleted:   req.ShowDeleted,
+		CustomerID:    req.CustomerId,
+		LabelSelector: req.LabelSelector,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &test.ListCustomerOrdersResponse{
+		Orders:        orders,
+		NextPageToken: next,
 	}, nil
 }
+
+// pageSize подставляет размер страницы по умолчанию и ограничивает его сверху.
+func pageSize(size int32) int32 {
+	if size == 0 {
+		return defaultPageSize
+	}
+	return min(size, maxPageSize)
+}
+
+// SearchOrders ищет заказы по названиям позиций, лучшие совпадения первыми.
+func (s *Serv) SearchOrders(ctx context.Context, req *test.SearchOrdersRequest) (*test.SearchOrdersResponse, error) {
+	orders, next, err := s.repo.Search(ctx, repository.SearchOptions{
+		Query:       req.Q,
+		PageSize:    pageSize(req.PageSize),
+		PageToken:   req.PageToken,
+		ShowDeleted: req.ShowDeleted,
+	})
+	if err != nil {
+		return nil, apierror.From(err, "search orders")
+	}
+	setEtag(orders...)
+	return &test.SearchOrdersResponse{
+		Orders:        orders,
+		NextPageToken: next,
+	}, nil
+}
+
+// list нормализует page_size и отдаёт страницу заказов с etag.
+func (s *Serv) list(ctx context.Context, size int32, opts repository.ListOptions) ([]*test.Order, string, error) {
+	if size < 0 {
+		return nil, "", status.Errorf(codes.InvalidArgument, "page_size must not be negative")
+	}
+
+	opts.PageSize = pageSize(size)
+
+	orders, next, err := s.repo.List(ctx, opts)
+	if err != nil {
+		return nil, "", apierror.From(err, "list orders")
+	}
+	setEtag(orders...)
+	return orders, next, nil
+}
+
+// StreamOrders отдаёт все подходящие заказы потоком, без страниц и без кэша.
+func (s *Serv) StreamOrders(req *test.StreamOrdersRequest, stream test.OrderService_StreamOrdersServer) error {
+	var sendErr error
+	err := s.repo.Stream(stream.Context(), repository.ListOptions{
+		Filter:        req.Filter,
+		OrderBy:       req.OrderBy,
+		ShowDeleted:   req.ShowDeleted,
+		LabelSelector: req.LabelSelector,
+	}, func(order *test.Order) error {
+		setEtag(order)
+		sendErr = stream.Send(&test.StreamOrdersResponse{Order: order})
+		return sendErr
+	})
+	if err != nil {
+		if sendErr != nil {
+			return err
+		}
+		return apierror.From(err, "stream orders")
+	}
+	return nil
+}
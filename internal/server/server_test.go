@@ -0,0 +1,243 @@
+package server
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"rpc/internal/idgen"
+	"rpc/internal/repository"
+	"rpc/pkg/api/test"
+)
+
+// fakeRepository хранит заказы в памяти. Методы, которые тесты не вызывают,
+// достаются от встроенного nil-интерфейса и паникуют.
+type fakeRepository struct {
+	repository.OrderRepository
+	orders   map[string]*test.Order
+	keys     map[string]repository.IdempotencyKey
+	keyIDs   map[string]string
+	updated  []string
+	listOpts repository.ListOptions
+	listErr  error
+}
+
+func newFakeRepository(orders ...*test.Order) *fakeRepository {
+	r := &fakeRepository{
+		orders: map[string]*test.Order{},
+		keys:   map[string]repository.IdempotencyKey{},
+		keyIDs: map[string]string{},
+	}
+	for _, o := range orders {
+		r.orders[o.Id] = proto.Clone(o).(*test.Order)
+	}
+	return r
+}
+
+func (r *fakeRepository) Create(ctx context.Context, order *test.Order) error {
+	if _, ok := r.orders[order.Id]; ok {
+		return status.Errorf(codes.AlreadyExists, "order with id %s already exists", order.Id)
+	}
+	r.orders[order.Id] = proto.Clone(order).(*test.Order)
+	return nil
+}
+
+func (r *fakeRepository) CreateWithKey(ctx context.Context, order *test.Order, key repository.IdempotencyKey) (string, error) {
+	if used, ok := r.keys[key.Key]; ok {
+		if used.RequestHash != key.RequestHash {
+			return "", status.Error(codes.InvalidArgument, "idempotency key was used for another request")
+		}
+		return r.keyIDs[key.Key], nil
+	}
+	r.keys[key.Key] = key
+	r.keyIDs[key.Key] = order.Id
+	return order.Id, r.Create(ctx, order)
+}
+
+func (r *fakeRepository) Get(ctx context.Context, id string) (*test.Order, error) {
+	o, ok := r.orders[id]
+	if !ok {
+		return nil, repository.OrderNotFound(id)
+	}
+	return proto.Clone(o).(*test.Order), nil
+}
+
+// Update повторяет проверку версии репозитория и запоминает записанные поля.
+func (r *fakeRepository) Update(ctx context.Context, order *test.Order, fields []string) error {
+	stored, ok := r.orders[order.Id]
+	if !ok {
+		return repository.OrderNotFound(order.Id)
+	}
+	if order.Version > 0 && order.Version != stored.Version {
+		return status.Error(codes.Aborted, "order version has changed")
+	}
+	order.Version = stored.Version + 1
+	r.orders[order.Id] = proto.Clone(order).(*test.Order)
+	r.updated = fields
+	return nil
+}
+
+func (r *fakeRepository) SetStatus(ctx context.Context, order *test.Order, to test.OrderStatus) error {
+	stored, ok := r.orders[order.Id]
+	if !ok {
+		return repository.OrderNotFound(order.Id)
+	}
+	if stored.Status != order.Status {
+		return status.Error(codes.FailedPrecondition, "order status has changed")
+	}
+	if order.Version > 0 && order.Version != stored.Version {
+		return status.Error(codes.Aborted, "order version has changed")
+	}
+	stored.Status = to
+	stored.Version++
+	order.Status = to
+	order.Version = stored.Version
+	return nil
+}
+
+func (r *fakeRepository) List(ctx context.Context, opts repository.ListOptions) ([]*test.Order, string, error) {
+	r.listOpts = opts
+	return nil, "", r.listErr
+}
+
+func newTestServer(repo repository.OrderRepository) *Serv {
+	ids, err := idgen.New(idgen.StrategyUUIDv7)
+	if err != nil {
+		panic(err)
+	}
+	return &Serv{repo: repo, ids: ids}
+}
+
+func TestListOrders(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      *test.ListOrdersRequest
+		listErr  error
+		wantOpts repository.ListOptions
+		wantCode codes.Code
+	}{
+		{
+			name:     "default page size",
+			req:      &test.ListOrdersRequest{Filter: "quantity > 1", OrderBy: "item desc", LabelSelector: "channel=web"},
+			wantOpts: repository.ListOptions{PageSize: defaultPageSize, Filter: "quantity > 1", OrderBy: "item desc", LabelSelector: "channel=web"},
+		},
+		{
+			name:     "page size is capped",
+			req:      &test.ListOrdersRequest{PageSize: maxPageSize + 1, PageToken: "abc"},
+			wantOpts: repository.ListOptions{PageSize: maxPageSize, PageToken: "abc"},
+		},
+		{name: "negative page size", req: &test.ListOrdersRequest{PageSize: -1}, wantCode: codes.InvalidArgument},
+		{
+			name:     "invalid filter is returned as is",
+			req:      &test.ListOrdersRequest{Filter: "price > 1"},
+			listErr:  status.Error(codes.InvalidArgument, `invalid filter: unknown field "price"`),
+			wantOpts: repository.ListOptions{PageSize: defaultPageSize, Filter: "price > 1"},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeRepository()
+			repo.listErr = tt.listErr
+
+			_, err := newTestServer(repo).ListOrders(context.Background(), tt.req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("ListOrders() code = %s, want %s (%v)", code, tt.wantCode, err)
+			}
+			if tt.wantCode == codes.InvalidArgument && tt.listErr == nil {
+				return
+			}
+			if !reflect.DeepEqual(repo.listOpts, tt.wantOpts) {
+				t.Errorf("List() options = %+v, want %+v", repo.listOpts, tt.wantOpts)
+			}
+		})
+	}
+}
+
+// Правило "item и quantity обязательны без line_items" связывает поля, поэтому
+// его проверяет сервер, а не интерцептор.
+func TestCreateOrderSingleLine(t *testing.T) {
+	tests := []struct {
+		name      string
+		req       *test.CreateOrderRequest
+		wantField string
+	}{
+		{name: "item and quantity", req: &test.CreateOrderRequest{Item: "Laptop", Quantity: 1}},
+		{name: "line items", req: &test.CreateOrderRequest{LineItems: []*test.LineItem{{Item: "Laptop", Quantity: 1}}}},
+		{name: "no item", req: &test.CreateOrderRequest{Quantity: 1}, wantField: "item"},
+		{name: "no quantity", req: &test.CreateOrderRequest{Item: "Laptop"}, wantField: "quantity"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeRepository()
+			_, err := newTestServer(repo).CreateOrder(context.Background(), tt.req)
+			if tt.wantField == "" {
+				if err != nil || len(repo.orders) != 1 {
+					t.Fatalf("CreateOrder() error = %v (%d orders), want one order", err, len(repo.orders))
+				}
+				return
+			}
+
+			st := status.Convert(err)
+			if st.Code() != codes.InvalidArgument || len(repo.orders) != 0 {
+				t.Fatalf("CreateOrder() error = %v (%d orders), want InvalidArgument", err, len(repo.orders))
+			}
+			details := st.Details()
+			if len(details) != 1 {
+				t.Fatalf("details = %v, want one BadRequest", details)
+			}
+			br, ok := details[0].(*errdetails.BadRequest)
+			if !ok || len(br.FieldViolations) != 1 || br.FieldViolations[0].Field != tt.wantField {
+				t.Errorf("details = %v, want violation of %s", details[0], tt.wantField)
+			}
+		})
+	}
+}
+
+func TestCreateOrderID(t *testing.T) {
+	tests := []struct {
+		strategy string
+		orderID  string
+		wantID   string
+		wantCode codes.Code
+	}{
+		{strategy: idgen.StrategyClient, orderID: "order-42", wantID: "order-42"},
+		{strategy: idgen.StrategyClient, orderID: "taken", wantCode: codes.AlreadyExists},
+		{strategy: idgen.StrategyULID},
+		{strategy: idgen.StrategyULID, orderID: "order-42", wantCode: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.strategy+"/"+tt.orderID, func(t *testing.T) {
+			repo := newFakeRepository(&test.Order{Id: "taken"})
+			s := newTestServer(repo)
+			var err error
+			if s.ids, err = idgen.New(tt.strategy); err != nil {
+				t.Fatalf("idgen.New(%q) error: %v", tt.strategy, err)
+			}
+
+			resp, err := s.CreateOrder(context.Background(), &test.CreateOrderRequest{Item: "Laptop", Quantity: 1, OrderId: tt.orderID})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("CreateOrder() code = %s, want %s (%v)", code, tt.wantCode, err)
+			}
+			if tt.wantCode != codes.OK {
+				if len(repo.orders) != 1 {
+					t.Errorf("orders = %d, want only the existing one", len(repo.orders))
+				}
+				return
+			}
+			if tt.wantID != "" && resp.Id != tt.wantID {
+				t.Errorf("CreateOrder() id = %q, want %q", resp.Id, tt.wantID)
+			}
+			if repo.orders[resp.Id] == nil {
+				t.Errorf("order %q is not stored", resp.Id)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"rpc/internal/apierror"
+	"rpc/internal/repository"
+	"rpc/internal/validate"
+	"rpc/pkg/api/test"
+)
+
+const (
+	defaultStatsItems = 100
+	// maxStatsRange ограничивает гистограмму по дням
+	maxStatsRange = 366 * 24 * time.Hour
+)
+
+// GetOrderStats отдаёт статистику заказов за окно [start_time, end_time).
+func (s *Serv) GetOrderStats(ctx context.Context, req *test.GetOrderStatsRequest) (*test.GetOrderStatsResponse, error) {
+	from, to := req.StartTime.AsTime(), req.EndTime.AsTime()
+	// правило связывает два поля, поэтому его нет в аннотациях api/order.proto
+	switch {
+	case !to.After(from):
+		return nil, validate.Error(fieldViolation("", &fieldError{field: "end_time", description: "value must be after start_time"}))
+	case to.Sub(from) > maxStatsRange:
+		return nil, validate.Error(fieldViolation("", &fieldError{field: "end_time", description: "range must not exceed 366 days"}))
+	}
+
+	limit := int(req.ItemLimit)
+	if limit == 0 {
+		limit = defaultStatsItems
+	}
+
+	stats, err := s.repo.Stats(ctx, repository.StatsOptions{From: from, To: to, ItemLimit: limit})
+	if err != nil {
+		return nil, apierror.From(err, "get order stats")
+	}
+	return &test.GetOrderStatsResponse{Stats: stats}, nil
+}
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"rpc/internal/apierror"
+	"rpc/pkg/api/test"
+)
+
+// transitions - допустимые переходы жизненного цикла заказа.
+// DELIVERED и CANCELLED - конечные состояния.
+var transitions = map[test.OrderStatus][]test.OrderStatus{
+	test.OrderStatus_ORDER_STATUS_PENDING:   {test.OrderStatus_ORDER_STATUS_CONFIRMED, test.OrderStatus_ORDER_STATUS_CANCELLED},
+	test.OrderStatus_ORDER_STATUS_CONFIRMED: {test.OrderStatus_ORDER_STATUS_PAID, test.OrderStatus_ORDER_STATUS_CANCELLED},
+	test.OrderStatus_ORDER_STATUS_PAID:      {test.OrderStatus_ORDER_STATUS_SHIPPED, test.OrderStatus_ORDER_STATUS_CANCELLED},
+	test.OrderStatus_ORDER_STATUS_SHIPPED:   {test.OrderStatus_ORDER_STATUS_DELIVERED},
+}
+
+func canTransition(from, to test.OrderStatus) bool {
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// transition переводит заказ в статус to, если это разрешено таблицей переходов.
+func (s *Serv) transition(ctx context.Context, id string, to test.OrderStatus) (*test.Order, error) {
+	order, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, apierror.From(err, "get order", "order_id", id)
+	}
+
+	if !canTransition(order.Status, to) {
+		return nil, apierror.New(codes.FailedPrecondition, apierror.ReasonInvalidTransition,
+			fmt.Sprintf("order %s cannot move from %s to %s", id, order.Status, to),
+			"order_id", id, "from", order.Status.String(), "to", to.String())
+	}
+
+	// переход и так условный по текущему статусу, версия из возможно
+	// устаревшего кэша здесь только мешала бы
+	order.Version = 0
+	err = s.repo.SetStatus(ctx, order, to)
+	if err != nil {
+		return nil, apierror.From(err, "update order status", "order_id", id)
+	}
+
+	setEtag(order)
+	return order, nil
+}
+
+func (s *Serv) ConfirmOrder(ctx context.Context, req *test.ConfirmOrderRequest) (*test.ConfirmOrderResponse, error) {
+	order, err := s.transition(ctx, req.Id, test.OrderStatus_ORDER_STATUS_CONFIRMED)
+	if err != nil {
+		return nil, err
+	}
+	return &test.ConfirmOrderResponse{Order: order}, nil
+}
+
+func (s *Serv) PayOrder(ctx context.Context, req *test.PayOrderRequest) (*test.PayOrderResponse, error) {
+	order, err := s.transition(ctx, req.Id, test.OrderStatus_ORDER_STATUS_PAID)
+	if err != nil {
+		return nil, err
+	}
+	return &test.PayOrderResponse{Order: order}, nil
+}
+
+func (s *Serv) ShipOrder(ctx context.Context, req *test.ShipOrderRequest) (*test.ShipOrderResponse, error) {
+	order, err := s.transition(ctx, req.Id, test.OrderStatus_ORDER_STATUS_SHIPPED)
+	if err != nil {
+		return nil, err
+	}
+	return &test.ShipOrderResponse{Order: order}, nil
+}
+
+func (s *Serv) DeliverOrder(ctx context.Context, req *test.DeliverOrderRequest) (*test.DeliverOrderResponse, error) {
+	order, err := s.transition(ctx, req.Id, test.OrderStatus_ORDER_STATUS_DELIVERED)
+	if err != nil {
+		return nil, err
+	}
+	return &test.DeliverOrderResponse{Order: order}, nil
+}
+
+func (s *Serv) CancelOrder(ctx context.Context, req *test.CancelOrderRequest) (*test.CancelOrderResponse, error) {
+	order, err := s.transition(ctx, req.Id, test.OrderStatus_ORDER_STATUS_CANCELLED)
+	if err != nil {
+		return nil, err
+	}
+	return &test.CancelOrderResponse{Order: order}, nil
+}
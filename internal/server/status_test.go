@@ -0,0 +1,90 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"rpc/pkg/api/test"
+)
+
+func TestCanTransition(t *testing.T) {
+	const (
+		pending   = test.OrderStatus_ORDER_STATUS_PENDING
+		confirmed = test.OrderStatus_ORDER_STATUS_CONFIRMED
+		paid      = test.OrderStatus_ORDER_STATUS_PAID
+		shipped   = test.OrderStatus_ORDER_STATUS_SHIPPED
+		delivered = test.OrderStatus_ORDER_STATUS_DELIVERED
+		cancelled = test.OrderStatus_ORDER_STATUS_CANCELLED
+	)
+
+	allowed := map[[2]test.OrderStatus]bool{
+		{pending, confirmed}:   true,
+		{pending, cancelled}:   true,
+		{confirmed, paid}:      true,
+		{confirmed, cancelled}: true,
+		{paid, shipped}:        true,
+		{paid, cancelled}:      true,
+		{shipped, delivered}:   true,
+	}
+
+	// все пары статусов, включая UNSPECIFIED, переходы в себя и из конечных состояний
+	for from := range test.OrderStatus_name {
+		for to := range test.OrderStatus_name {
+			from, to := test.OrderStatus(from), test.OrderStatus(to)
+			t.Run(from.String()+"->"+to.String(), func(t *testing.T) {
+				want := allowed[[2]test.OrderStatus{from, to}]
+				if got := canTransition(from, to); got != want {
+					t.Errorf("canTransition(%s, %s) = %v, want %v", from, to, got, want)
+				}
+			})
+		}
+	}
+
+	for _, terminal := range []test.OrderStatus{delivered, cancelled} {
+		if next := transitions[terminal]; len(next) != 0 {
+			t.Errorf("terminal status %s has transitions %v", terminal, next)
+		}
+	}
+}
+
+func TestTransition(t *testing.T) {
+	repo := newFakeRepository(
+		&test.Order{Id: "pending", Status: test.OrderStatus_ORDER_STATUS_PENDING},
+		&test.Order{Id: "delivered", Status: test.OrderStatus_ORDER_STATUS_DELIVERED},
+	)
+	s := newTestServer(repo)
+
+	tests := []struct {
+		name     string
+		id       string
+		to       test.OrderStatus
+		wantCode codes.Code
+	}{
+		{name: "allowed", id: "pending", to: test.OrderStatus_ORDER_STATUS_CONFIRMED},
+		{name: "skips a step", id: "pending", to: test.OrderStatus_ORDER_STATUS_SHIPPED, wantCode: codes.FailedPrecondition},
+		{name: "from a terminal state", id: "delivered", to: test.OrderStatus_ORDER_STATUS_CANCELLED, wantCode: codes.FailedPrecondition},
+		{name: "missing order", id: "missing", to: test.OrderStatus_ORDER_STATUS_CONFIRMED, wantCode: codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := repo.orders[tt.id].GetStatus()
+
+			order, err := s.transition(context.Background(), tt.id, tt.to)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("transition() code = %s, want %s (%v)", code, tt.wantCode, err)
+			}
+			if tt.wantCode != codes.OK {
+				if got := repo.orders[tt.id].GetStatus(); got != before {
+					t.Errorf("stored status = %s, want unchanged %s", got, before)
+				}
+				return
+			}
+			if order.Status != tt.to || repo.orders[tt.id].Status != tt.to {
+				t.Errorf("status = %s, stored %s, want %s", order.Status, repo.orders[tt.id].Status, tt.to)
+			}
+		})
+	}
+}
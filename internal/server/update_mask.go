@@ -0,0 +1,152 @@
+package server
+
+import (
+	"fmt"
+
+	"google.golang.org/genproto/googleapis/type/money"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"rpc/pkg/api/test"
+)
+
+// updatableFields - поля Order, которые можно менять через UpdateOrder.
+var updatableFields = []string{"item", "quantity", "line_items", "labels"}
+
+// resolveMask проверяет update_mask и раскрывает его в список полей.
+// Пустая маска означает все заполненные в order поля, "*" - все изменяемые поля:
+// заказ целиком заменяется тем, что пришло в order.
+func resolveMask(mask *fieldmaskpb.FieldMask, order *test.Order) ([]string, error) {
+	if len(mask.GetPaths()) == 0 {
+		var paths []string
+		if order.Item != "" {
+			paths = append(paths, "item")
+		}
+		if order.Quantity != 0 {
+			paths = append(paths, "quantity")
+		}
+		if len(order.LineItems) > 0 {
+			paths = append(paths, "line_items")
+		}
+		if len(order.Labels) > 0 {
+			paths = append(paths, "labels")
+		}
+		if len(paths) == 0 {
+			return nil, fmt.Errorf("nothing to update")
+		}
+		return paths, nil
+	}
+
+	seen := make(map[string]bool)
+	var paths []string
+	for _, path := range mask.GetPaths() {
+		if path == "*" {
+			if len(mask.GetPaths()) != 1 {
+				return nil, fmt.Errorf("wildcard update_mask cannot be combined with other paths")
+			}
+			// копия: вызывающий может дописывать в список
+			return append([]string(nil), updatableFields...), nil
+		}
+		if !isUpdatable(path) {
+			return nil, fmt.Errorf("field %q cannot be updated", path)
+		}
+		if !seen[path] {
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+
+	return paths, nil
+}
+
+func isUpdatable(path string) bool {
+	for _, f := range updatableFields {
+		if f == path {
+			return true
+		}
+	}
+	return false
+}
+
+func hasPath(paths []string, path string) bool {
+	for _, p := range paths {
+		if p == path {
+			return true
+		}
+	}
+	return false
+}
+
+// applyMask переносит поля из patch в копию current и возвращает её вместе со списком
+// полей, которые нужно записать. item и quantity - краткий итог позиций, поэтому
+// правка позиций тянет за собой и их, а правка item или quantity переписывает
+// единственную позицию заказа. labels от позиций не зависят и заменяются целиком.
+func applyMask(current, patch *test.Order, paths []string) (*test.Order, []string, error) {
+	merged := proto.Clone(current).(*test.Order)
+
+	if hasPath(paths, "labels") {
+		merged.Labels = patch.Labels
+	}
+	if !hasPath(paths, "item") && !hasPath(paths, "quantity") && !hasPath(paths, "line_items") {
+		return merged, paths, nil
+	}
+
+	if hasPath(paths, "line_items") {
+		merged.LineItems = patch.LineItems
+		// при замене всех полей заказ без line_items состоит из одной позиции item/quantity
+		if len(merged.LineItems) == 0 && hasPath(paths, "item") && hasPath(paths, "quantity") {
+			if patch.Item == "" {
+				return nil, nil, &fieldError{field: "item", description: "value is required when line_items is empty"}
+			}
+			if patch.Quantity <= 0 {
+				return nil, nil, &fieldError{field: "quantity", description: "value must be greater than 0 when line_items is empty"}
+			}
+			merged.LineItems = []*test.LineItem{{Item: patch.Item, Quantity: patch.Quantity}}
+		}
+		if len(merged.LineItems) == 0 {
+			return nil, nil, fmt.Errorf("order must have at least one line item")
+		}
+		if err := summarize(merged); err != nil {
+			return nil, nil, err
+		}
+		if err := price(merged); err != nil {
+			return nil, nil, err
+		}
+		fields := []string{"item", "quantity", "line_items"}
+		if hasPath(paths, "labels") {
+			fields = append(fields, "labels")
+		}
+		return merged, fields, nil
+	}
+
+	if len(current.LineItems) > 1 {
+		return nil, nil, fmt.Errorf("order %s has several line items, update line_items instead of item and quantity", current.Id)
+	}
+
+	if hasPath(paths, "item") {
+		merged.Item = patch.Item
+	}
+	if hasPath(paths, "quantity") {
+		merged.Quantity = patch.Quantity
+	}
+	// единственная позиция пересобирается из item/quantity, цена за единицу сохраняется
+	var unitPrice *money.Money
+	if len(current.LineItems) == 1 {
+		unitPrice = current.LineItems[0].UnitPrice
+	}
+	merged.LineItems = nil
+	if err := summarize(merged); err != nil {
+		return nil, nil, err
+	}
+	merged.LineItems[0].UnitPrice = unitPrice
+	if merged.Item == "" {
+		return nil, nil, &fieldError{field: "item", description: "value is required"}
+	}
+	if merged.Quantity <= 0 {
+		return nil, nil, &fieldError{field: "quantity", description: "value must be greater than 0"}
+	}
+	if err := price(merged); err != nil {
+		return nil, nil, err
+	}
+
+	return merged, append(paths, "line_items"), nil
+}
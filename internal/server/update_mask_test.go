@@ -0,0 +1,329 @@
+package server
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/type/money"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"rpc/pkg/api/test"
+)
+
+func TestResolveMask(t *testing.T) {
+	tests := []struct {
+		name    string
+		paths   []string
+		order   *test.Order
+		want    []string
+		wantErr string
+	}{
+		{
+			name:  "empty mask takes set fields",
+			order: &test.Order{Item: "Laptop", LineItems: []*test.LineItem{{Item: "Laptop", Quantity: 1}}, Labels: map[string]string{"a": "b"}},
+			want:  []string{"item", "line_items", "labels"},
+		},
+		{
+			name:    "empty mask and empty order",
+			order:   &test.Order{},
+			wantErr: "nothing to update",
+		},
+		{name: "wildcard", paths: []string{"*"}, order: &test.Order{}, want: updatableFields},
+		{name: "wildcard with others", paths: []string{"*", "item"}, order: &test.Order{}, wantErr: "cannot be combined"},
+		{name: "duplicates", paths: []string{"quantity", "item", "quantity"}, order: &test.Order{}, want: []string{"quantity", "item"}},
+		{name: "not updatable", paths: []string{"status"}, order: &test.Order{}, wantErr: `field "status" cannot be updated`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mask *fieldmaskpb.FieldMask
+			if tt.paths != nil {
+				mask = &fieldmaskpb.FieldMask{Paths: tt.paths}
+			}
+			got, err := resolveMask(mask, tt.order)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("resolveMask() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveMask() error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("resolveMask() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	got, _ := resolveMask(&fieldmaskpb.FieldMask{Paths: []string{"*"}}, &test.Order{})
+	got[0] = "changed"
+	if updatableFields[0] != "item" {
+		t.Errorf("resolveMask() returned updatableFields itself, not a copy")
+	}
+}
+
+func TestApplyMask(t *testing.T) {
+	usd := func(units int64) *money.Money {
+		return &money.Money{CurrencyCode: "USD", Units: units}
+	}
+	single := &test.Order{
+		Id:        "order-1",
+		Item:      "Laptop",
+		Quantity:  2,
+		LineItems: []*test.LineItem{{Item: "Laptop", Quantity: 2, UnitPrice: usd(10)}},
+		Total:     usd(20),
+		Labels:    map[string]string{"channel": "web"},
+	}
+	multi := &test.Order{
+		Id:        "order-2",
+		Item:      "Laptop",
+		Quantity:  3,
+		LineItems: []*test.LineItem{{Item: "Laptop", Quantity: 1}, {Item: "Mouse", Quantity: 2}},
+	}
+
+	tests := []struct {
+		name       string
+		current    *test.Order
+		patch      *test.Order
+		paths      []string
+		wantFields []string
+		want       *test.Order
+		wantErr    string
+	}{
+		{
+			name:       "labels only",
+			current:    single,
+			patch:      &test.Order{Labels: map[string]string{"region": "eu"}},
+			paths:      []string{"labels"},
+			wantFields: []string{"labels"},
+			want: &test.Order{
+				Id: "order-1", Item: "Laptop", Quantity: 2,
+				LineItems: []*test.LineItem{{Item: "Laptop", Quantity: 2, UnitPrice: usd(10)}},
+				Total:     usd(20),
+				Labels:    map[string]string{"region": "eu"},
+			},
+		},
+		{
+			name:    "line items update the summary",
+			current: single,
+			patch: &test.Order{LineItems: []*test.LineItem{
+				{Item: "Mouse", Quantity: 1, UnitPrice: usd(5)},
+				{Item: "Pad", Quantity: 4, UnitPrice: usd(1)},
+			}},
+			paths:      []string{"line_items", "labels"},
+			wantFields: []string{"item", "quantity", "line_items", "labels"},
+			want: &test.Order{
+				Id: "order-1", Item: "Mouse", Quantity: 5,
+				LineItems: []*test.LineItem{
+					{Item: "Mouse", Quantity: 1, UnitPrice: usd(5)},
+					{Item: "Pad", Quantity: 4, UnitPrice: usd(1)},
+				},
+				Total: usd(9),
+			},
+		},
+		{
+			name:       "quantity keeps the unit price",
+			current:    single,
+			patch:      &test.Order{Quantity: 3},
+			paths:      []string{"quantity"},
+			wantFields: []string{"quantity", "line_items"},
+			want: &test.Order{
+				Id: "order-1", Item: "Laptop", Quantity: 3,
+				LineItems: []*test.LineItem{{Item: "Laptop", Quantity: 3, UnitPrice: usd(10)}},
+				Labels:    map[string]string{"channel": "web"},
+				Total:     usd(30),
+			},
+		},
+		{
+			name:       "line items without prices drop the total",
+			current:    single,
+			patch:      &test.Order{LineItems: []*test.LineItem{{Item: "Mouse", Quantity: 1}}},
+			paths:      []string{"line_items"},
+			wantFields: []string{"item", "quantity", "line_items"},
+			want: &test.Order{
+				Id: "order-1", Item: "Mouse", Quantity: 1,
+				LineItems: []*test.LineItem{{Item: "Mouse", Quantity: 1}},
+				Labels:    map[string]string{"channel": "web"},
+			},
+		},
+		{
+			name:    "mixed currencies",
+			current: single,
+			patch: &test.Order{LineItems: []*test.LineItem{
+				{Item: "Mouse", Quantity: 1, UnitPrice: usd(5)},
+				{Item: "Pad", Quantity: 1, UnitPrice: &money.Money{CurrencyCode: "EUR", Units: 1}},
+			}},
+			paths:   []string{"line_items"},
+			wantErr: "currency EUR differs from USD",
+		},
+		{
+			name:       "wildcard with item and quantity",
+			current:    multi,
+			patch:      &test.Order{Item: "Tablet", Quantity: 4},
+			paths:      []string{"item", "quantity", "line_items", "labels"},
+			wantFields: []string{"item", "quantity", "line_items", "labels"},
+			want: &test.Order{
+				Id: "order-2", Item: "Tablet", Quantity: 4,
+				LineItems: []*test.LineItem{{Item: "Tablet", Quantity: 4}},
+			},
+		},
+		{
+			name:    "wildcard without quantity",
+			current: single,
+			patch:   &test.Order{Item: "Tablet"},
+			paths:   []string{"item", "quantity", "line_items", "labels"},
+			wantErr: "quantity: value must be greater than 0 when line_items is empty",
+		},
+		{
+			name:    "empty line items",
+			current: single,
+			patch:   &test.Order{},
+			paths:   []string{"line_items"},
+			wantErr: "at least one line item",
+		},
+		{
+			name:    "total quantity overflows",
+			current: single,
+			patch: &test.Order{LineItems: []*test.LineItem{
+				{Item: "Laptop", Quantity: math.MaxInt32},
+				{Item: "Mouse", Quantity: 1},
+			}},
+			paths:   []string{"line_items"},
+			wantErr: "total quantity must not exceed 2147483647",
+		},
+		{
+			name:    "item on a multi-line order",
+			current: multi,
+			patch:   &test.Order{Item: "Tablet"},
+			paths:   []string{"item"},
+			wantErr: "has several line items",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := proto.Clone(tt.current)
+			got, fields, err := applyMask(tt.current, tt.patch, tt.paths)
+			if !proto.Equal(tt.current, before) {
+				t.Fatalf("applyMask() modified current order")
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("applyMask() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("applyMask() error: %v", err)
+			}
+			if !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Errorf("applyMask() fields = %q, want %q", fields, tt.wantFields)
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("applyMask() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateOrder(t *testing.T) {
+	current := &test.Order{
+		Id:        "order-1",
+		Item:      "Laptop",
+		Quantity:  2,
+		LineItems: []*test.LineItem{{Item: "Laptop", Quantity: 2}},
+		Version:   1,
+	}
+
+	tests := []struct {
+		name       string
+		req        *test.UpdateOrderRequest
+		want       *test.Order
+		wantFields []string
+		wantCode   codes.Code
+	}{
+		{
+			name: "mask limits the change",
+			req: &test.UpdateOrderRequest{
+				Order:      &test.Order{Id: "order-1", Item: "Tablet", Quantity: 9},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"quantity"}},
+			},
+			want: &test.Order{
+				Id: "order-1", Item: "Laptop", Quantity: 9,
+				LineItems: []*test.LineItem{{Item: "Laptop", Quantity: 9}},
+				Version:   2, Etag: `"2"`,
+			},
+			wantFields: []string{"quantity", "line_items"},
+		},
+		{
+			name: "empty mask takes set fields",
+			req:  &test.UpdateOrderRequest{Order: &test.Order{Id: "order-1", Item: "Tablet"}},
+			want: &test.Order{
+				Id: "order-1", Item: "Tablet", Quantity: 2,
+				LineItems: []*test.LineItem{{Item: "Tablet", Quantity: 2}},
+				Version:   2, Etag: `"2"`,
+			},
+			wantFields: []string{"item", "line_items"},
+		},
+		{
+			name: "wildcard replaces the order",
+			req: &test.UpdateOrderRequest{
+				Order:      &test.Order{Id: "order-1", Item: "Tablet", Quantity: 5},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"*"}},
+			},
+			want: &test.Order{
+				Id: "order-1", Item: "Tablet", Quantity: 5,
+				LineItems: []*test.LineItem{{Item: "Tablet", Quantity: 5}},
+				Version:   2, Etag: `"2"`,
+			},
+			wantFields: []string{"item", "quantity", "line_items", "labels"},
+		},
+		{
+			name: "invalid mask",
+			req: &test.UpdateOrderRequest{
+				Order:      &test.Order{Id: "order-1", Item: "Tablet"},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"id"}},
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "missing order",
+			req: &test.UpdateOrderRequest{
+				Order:      &test.Order{Id: "order-2", Item: "Tablet"},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"item"}},
+			},
+			wantCode: codes.NotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeRepository(current)
+
+			resp, err := newTestServer(repo).UpdateOrder(context.Background(), tt.req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("UpdateOrder() code = %s, want %s (%v)", code, tt.wantCode, err)
+			}
+			if tt.wantCode != codes.OK {
+				if !proto.Equal(repo.orders[current.Id], current) {
+					t.Errorf("stored order = %v, want unchanged", repo.orders[current.Id])
+				}
+				return
+			}
+			stored := repo.orders[current.Id]
+			setEtag(stored)
+			if !proto.Equal(resp.Order, tt.want) || !proto.Equal(stored, tt.want) {
+				t.Errorf("UpdateOrder() = %v, stored %v, want %v", resp.Order, stored, tt.want)
+			}
+			if !reflect.DeepEqual(repo.updated, tt.wantFields) {
+				t.Errorf("Update() fields = %q, want %q", repo.updated, tt.wantFields)
+			}
+		})
+	}
+}
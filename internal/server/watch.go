@@ -0,0 +1,146 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"rpc/internal/apierror"
+	"rpc/internal/repository"
+	"rpc/internal/watch"
+	"rpc/pkg/api/test"
+)
+
+// watchReplayBatch - по сколько записей журнала отдавать при возобновлении.
+const watchReplayBatch = 500
+
+var changeTypes = map[repository.ChangeType]test.OrderEventType{
+	repository.ChangeCreated: test.OrderEventType_ORDER_EVENT_TYPE_CREATED,
+	repository.ChangeUpdated: test.OrderEventType_ORDER_EVENT_TYPE_UPDATED,
+	repository.ChangeDeleted: test.OrderEventType_ORDER_EVENT_TYPE_DELETED,
+}
+
+// resumeToken кодирует позицию в журнале изменений как "<txid>.<seq>".
+func resumeToken(pos repository.Position) string {
+	return strconv.FormatInt(pos.TxID, 10) + "." + strconv.FormatInt(pos.Seq, 10)
+}
+
+func parseResumeToken(token string) (repository.Position, error) {
+	if token == "" {
+		return repository.Position{}, nil
+	}
+	txid, seq, ok := strings.Cut(token, ".")
+	if !ok {
+		return repository.Position{}, errors.New("malformed resume_token")
+	}
+
+	var pos repository.Position
+	var errTx, errSeq error
+	pos.TxID, errTx = strconv.ParseInt(txid, 10, 64)
+	pos.Seq, errSeq = strconv.ParseInt(seq, 10, 64)
+	if errTx != nil || errSeq != nil || pos.TxID <= 0 || pos.Seq <= 0 {
+		return repository.Position{}, errors.New("malformed resume_token")
+	}
+	return pos, nil
+}
+
+func resumeTokenExpired(token string) error {
+	return apierror.New(codes.OutOfRange, apierror.ReasonResumeTokenExpired,
+		fmt.Sprintf("resume_token %s has expired, re-read orders with ListOrders", token),
+		"resume_token", token)
+}
+
+// WatchOrders отдаёт изменения заказов по мере их появления. С resume_token
+// сначала досылаются изменения из журнала после него, затем живые.
+func (s *Serv) WatchOrders(req *test.WatchOrdersRequest, stream test.OrderService_WatchOrdersServer) error {
+	if s.hub == nil {
+		return status.Errorf(codes.Unimplemented, "order changes feed is not configured")
+	}
+	ctx := stream.Context()
+
+	after, err := parseResumeToken(req.ResumeToken)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	// подписка раньше чтения журнала, чтобы между ними ничего не потерялось;
+	// то, что придёт и туда, и туда, отсеивается по позиции
+	sub := s.hub.Subscribe()
+	defer sub.Close()
+
+	if !after.IsZero() {
+		retained, err := s.hub.Retained(ctx, after)
+		if err != nil {
+			return apierror.From(err, "read order changes")
+		}
+		if !retained {
+			return resumeTokenExpired(req.ResumeToken)
+		}
+
+		for {
+			changes, err := s.hub.Replay(ctx, after, watchReplayBatch)
+			if err != nil {
+				return apierror.From(err, "read order changes")
+			}
+			for _, change := range changes {
+				if err := s.sendChange(stream, change); err != nil {
+					return err
+				}
+				after = change.Position
+			}
+			if len(changes) < watchReplayBatch {
+				break
+			}
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case change, ok := <-sub.C:
+			if !ok {
+				if errors.Is(sub.Err(), watch.ErrLagging) {
+					return apierror.New(codes.ResourceExhausted, apierror.ReasonWatchLagging,
+						"watch stream fell behind, resume with the last resume_token")
+				}
+				return status.Errorf(codes.Unavailable, "order changes feed stopped, resume with the last resume_token")
+			}
+			// хаб публикует по возрастанию позиций, всё до after уже отправлено
+			if !after.Before(change.Position) {
+				continue
+			}
+			if err := s.sendChange(stream, change); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// sendChange отправляет изменение вместе с текущим состоянием заказа.
+// Если заказ успели удалить, Order остаётся пустым.
+func (s *Serv) sendChange(stream test.OrderService_WatchOrdersServer, change repository.Change) error {
+	resp := &test.WatchOrdersResponse{
+		Type:        changeTypes[change.Type],
+		OrderId:     change.OrderID,
+		EventTime:   timestamppb.New(change.Time),
+		ResumeToken: resumeToken(change.Position),
+	}
+
+	if change.Type != repository.ChangeDeleted {
+		order, err := s.repo.Get(stream.Context(), change.OrderID)
+		switch {
+		case err == nil:
+			setEtag(order)
+			resp.Order = order
+		case status.Code(err) != codes.NotFound:
+			return apierror.From(err, "get order", "order_id", change.OrderID)
+		}
+	}
+
+	return stream.Send(resp)
+}
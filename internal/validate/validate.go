@@ -0,0 +1,55 @@
+// Package validate проверяет сообщения по аннотациям buf.validate из api/order.proto
+// через protovalidate и переводит нарушения в google.rpc.BadRequest.
+package validate
+
+import (
+	"errors"
+	"fmt"
+
+	"buf.build/go/protovalidate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Message возвращает все нарушения правил в msg; пути полей - как в JSON-запросе
+// по именам proto, например line_items[0].quantity. Ошибка означает, что правила
+// не удалось применить (например, CEL-выражение не компилируется), а не что msg плох.
+func Message(msg proto.Message) ([]*errdetails.BadRequest_FieldViolation, error) {
+	err := protovalidate.Validate(msg)
+	if err == nil {
+		return nil, nil
+	}
+
+	var invalid *protovalidate.ValidationError
+	if !errors.As(err, &invalid) {
+		return nil, err
+	}
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(invalid.Violations))
+	for _, v := range invalid.Violations {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       protovalidate.FieldPathString(v.Proto.GetField()),
+			Description: v.Proto.GetMessage(),
+		})
+	}
+	return violations, nil
+}
+
+// Error собирает InvalidArgument с google.rpc.BadRequest; gateway отдаёт его как 400
+// с перечнем полей в details.
+func Error(violations ...*errdetails.BadRequest_FieldViolation) error {
+	msg := "invalid request"
+	if len(violations) > 0 {
+		msg = fmt.Sprintf("invalid request: %s: %s", violations[0].Field, violations[0].Description)
+		if len(violations) > 1 {
+			msg += fmt.Sprintf(" (and %d more)", len(violations)-1)
+		}
+	}
+
+	st := status.New(codes.InvalidArgument, msg)
+	if withDetails, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
@@ -0,0 +1,118 @@
+package validate
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"rpc/pkg/api/test"
+)
+
+func TestMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  proto.Message
+		want []string
+	}{
+		{
+			name: "valid",
+			msg:  &test.CreateOrderRequest{Item: "Laptop", Quantity: 2, CustomerId: "customer-1"},
+		},
+		{
+			name: "string length counts characters",
+			msg:  &test.GetOrderRequest{Id: strings.Repeat("я", 36)},
+		},
+		{
+			name: "string bounds",
+			msg:  &test.GetOrderRequest{Id: strings.Repeat("a", 37)},
+			want: []string{"id: value length must be at most 36 characters"},
+		},
+		{
+			name: "min_len",
+			msg:  &test.GetOrderRequest{},
+			want: []string{"id: value length must be at least 1 characters"},
+		},
+		{
+			name: "pattern",
+			msg:  &test.WatchOrdersRequest{ResumeToken: "12a"},
+			want: []string{"resume_token: value does not match regex pattern `^([0-9]+\\.[0-9]+)?$`"},
+		},
+		{
+			name: "nested list items",
+			msg: &test.CreateOrderRequest{LineItems: []*test.LineItem{
+				{Item: "Laptop", Quantity: 1},
+				{Item: "", Quantity: 0},
+			}},
+			want: []string{
+				"line_items[1].item: value length must be at least 1 characters",
+				"line_items[1].quantity: value must be greater than 0",
+			},
+		},
+		{
+			name: "map keys and values",
+			msg:  &test.CreateOrderRequest{Item: "Laptop", Quantity: 1, Labels: map[string]string{"-bad": "ok"}},
+			want: []string{`labels["-bad"]: value does not match regex pattern ` + "`^[A-Za-z0-9]([A-Za-z0-9._/-]*[A-Za-z0-9])?$`"},
+		},
+		{
+			name: "repeated scalar items",
+			msg:  &test.BatchGetOrdersRequest{Ids: []string{"a", ""}},
+			want: []string{"ids[1]: value length must be at least 1 characters"},
+		},
+		{
+			name: "required message",
+			msg:  &test.UpdateOrderRequest{},
+			want: []string{"order: value is required"},
+		},
+		{
+			name: "int64 range",
+			msg:  &test.RestockItemRequest{Item: "Laptop", Quantity: 1_000_000_001},
+			want: []string{"quantity: value must be greater than 0 and less than or equal to 1000000000"},
+		},
+		{
+			name: "not_in",
+			msg:  &test.AdjustStockRequest{Item: "Laptop", Delta: 0},
+			want: []string{"delta: value must not be in list [0]"},
+		},
+		{
+			name: "negative delta",
+			msg:  &test.AdjustStockRequest{Item: "Laptop", Delta: -5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			violations, err := Message(tt.msg)
+			if err != nil {
+				t.Fatalf("Message() error: %v", err)
+			}
+			for _, v := range violations {
+				got = append(got, v.Field+": "+v.Description)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Message() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestError(t *testing.T) {
+	violations, err := Message(&test.CreateOrderRequest{LineItems: []*test.LineItem{{}}})
+	if err != nil {
+		t.Fatalf("Message() error: %v", err)
+	}
+	st := status.Convert(Error(violations...))
+
+	if st.Code() != codes.InvalidArgument {
+		t.Errorf("code = %s, want InvalidArgument", st.Code())
+	}
+	if want := "invalid request: line_items[0].item: value length must be at least 1 characters (and 1 more)"; st.Message() != want {
+		t.Errorf("message = %q, want %q", st.Message(), want)
+	}
+	if len(st.Details()) != 1 {
+		t.Errorf("details = %v, want one BadRequest", st.Details())
+	}
+}
@@ -0,0 +1,197 @@
+// Package watch раздаёт изменения заказов из журнала Postgres подписчикам
+// WatchOrders внутри процесса: на весь процесс держится одно соединение с LISTEN.
+package watch
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"rpc/internal/repository"
+)
+
+const (
+	// subscriberBuffer - сколько изменений подписчик может не вычитать, прежде чем его отключат.
+	subscriberBuffer = 256
+	// readBatch - по сколько записей журнала читать за раз.
+	readBatch = 500
+	// pollInterval - как часто перечитывать журнал без уведомлений: записи транзакции,
+	// которая зафиксировалась раньше более старой, становятся доступны только после
+	// завершения той, и отдельного NOTIFY на это не приходит.
+	pollInterval = time.Second
+	reconnectGap = time.Second
+)
+
+// ErrLagging - подписчик не успевал вычитывать изменения и был отключён.
+var ErrLagging = errors.New("subscriber is too slow")
+
+// Subscription - поток изменений для одного подписчика.
+// C закрывается после Close или при отставании подписчика, причина - в Err.
+type Subscription struct {
+	C    <-chan repository.Change
+	ch   chan repository.Change
+	hub  *Hub
+	err  error
+	once sync.Once
+}
+
+func (s *Subscription) Err() error {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	return s.err
+}
+
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	s.hub.drop(s, nil)
+}
+
+type Hub struct {
+	log    repository.ChangeLog
+	logger *zap.Logger
+
+	mu   sync.Mutex
+	subs map[*Subscription]struct{}
+
+	// readMu не даёт читать журнал параллельно по уведомлению и по таймеру
+	readMu  sync.Mutex
+	started bool
+	last    repository.Position
+}
+
+func NewHub(log repository.ChangeLog, logger *zap.Logger) *Hub {
+	return &Hub{
+		log:    log,
+		logger: logger,
+		subs:   make(map[*Subscription]struct{}),
+	}
+}
+
+// Subscribe подписывает на изменения, опубликованные после вызова.
+func (h *Hub) Subscribe() *Subscription {
+	ch := make(chan repository.Change, subscriberBuffer)
+	sub := &Subscription{C: ch, ch: ch, hub: h}
+
+	h.mu.Lock()
+	h.subs[sub] = struct{}{}
+	h.mu.Unlock()
+
+	return sub
+}
+
+// Run слушает журнал и переподключается при обрыве, пока не отменят ctx.
+// После отмены все подписки закрываются.
+func (h *Hub) Run(ctx context.Context) {
+	defer func() {
+		h.mu.Lock()
+		for sub := range h.subs {
+			h.drop(sub, ctx.Err())
+		}
+		h.mu.Unlock()
+	}()
+
+	go h.poll(ctx)
+
+	for {
+		err := h.log.Listen(ctx, func() { h.read(ctx) }, func() { h.read(ctx) })
+		if ctx.Err() != nil {
+			return
+		}
+		h.logger.Error("order changes listener stopped, reconnecting", zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectGap):
+		}
+	}
+}
+
+func (h *Hub) poll(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.read(ctx)
+		}
+	}
+}
+
+// read публикует записи журнала после последней опубликованной, в том числе всё,
+// что пришло, пока соединения не было. При первом чтении публиковать нечего:
+// запоминается только текущий конец журнала.
+func (h *Hub) read(ctx context.Context) {
+	h.readMu.Lock()
+	defer h.readMu.Unlock()
+
+	if !h.started {
+		latest, err := h.log.Latest(ctx)
+		if err != nil {
+			h.logger.Error("failed to read order changes", zap.Error(err))
+			return
+		}
+		h.last, h.started = latest, true
+		return
+	}
+
+	for {
+		changes, err := h.log.After(ctx, h.last, readBatch)
+		if err != nil {
+			if ctx.Err() == nil {
+				h.logger.Error("failed to read order changes", zap.Error(err))
+			}
+			return
+		}
+		for _, change := range changes {
+			h.publish(change)
+			h.last = change.Position
+		}
+		if len(changes) < readBatch {
+			return
+		}
+	}
+}
+
+func (h *Hub) publish(change repository.Change) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subs {
+		select {
+		case sub.ch <- change:
+		default:
+			h.drop(sub, ErrLagging)
+		}
+	}
+}
+
+// drop вызывается под h.mu.
+func (h *Hub) drop(sub *Subscription, err error) {
+	sub.once.Do(func() {
+		delete(h.subs, sub)
+		sub.err = err
+		close(sub.ch)
+	})
+}
+
+// Replay возвращает до limit изменений из журнала после pos.
+func (h *Hub) Replay(ctx context.Context, pos repository.Position, limit int) ([]repository.Change, error) {
+	return h.log.After(ctx, pos, limit)
+}
+
+// Retained сообщает, есть ли ещё в журнале все изменения после pos,
+// или часть из них уже удалена очисткой.
+func (h *Hub) Retained(ctx context.Context, pos repository.Position) (bool, error) {
+	purged, err := h.log.Purged(ctx)
+	if err != nil {
+		return false, err
+	}
+	return !pos.Before(purged), nil
+}
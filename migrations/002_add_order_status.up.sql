@@ -0,0 +1,5 @@
+ALTER TABLE orders
+    ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'PENDING'
+        CHECK (status IN ('PENDING', 'CONFIRMED', 'PAID', 'SHIPPED', 'DELIVERED', 'CANCELLED'));
+
+CREATE INDEX idx_orders_status ON orders(status);
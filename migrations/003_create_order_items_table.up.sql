@@ -0,0 +1,11 @@
+CREATE TABLE order_items (
+                        order_id VARCHAR(36) NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+                        line_no INTEGER NOT NULL,
+                        item VARCHAR(255) NOT NULL,
+                        quantity INTEGER NOT NULL,
+                        PRIMARY KEY (order_id, line_no)
+);
+
+-- существующие заказы становятся заказами из одной позиции
+INSERT INTO order_items (order_id, line_no, item, quantity)
+SELECT id, 1, item, quantity FROM orders;
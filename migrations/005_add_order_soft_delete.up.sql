@@ -0,0 +1,3 @@
+ALTER TABLE orders ADD COLUMN deleted_at TIMESTAMP;
+
+CREATE INDEX idx_orders_deleted_at ON orders(deleted_at) WHERE deleted_at IS NOT NULL;
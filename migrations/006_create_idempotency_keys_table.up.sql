@@ -0,0 +1,9 @@
+CREATE TABLE idempotency_keys (
+                        key VARCHAR(255) PRIMARY KEY,
+                        request_hash VARCHAR(64) NOT NULL,
+                        order_id VARCHAR(36) NOT NULL,
+                        created_at TIMESTAMP DEFAULT NOW(),
+                        expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
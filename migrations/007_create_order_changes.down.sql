@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS orders_notify_delete ON orders;
+DROP TRIGGER IF EXISTS orders_notify_update ON orders;
+DROP TRIGGER IF EXISTS orders_notify_insert ON orders;
+
+DROP FUNCTION IF EXISTS notify_order_changes();
+
+DROP TABLE IF EXISTS order_changes_purged;
+
+DROP TABLE IF EXISTS order_changes;
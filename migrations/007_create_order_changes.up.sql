@@ -0,0 +1,67 @@
+-- позиция в журнале - (txid, seq): seq выдаётся при вставке, а транзакции фиксируются
+-- в другом порядке, поэтому запись читается, только когда все транзакции с меньшим
+-- txid уже завершены. Так позиции выдаются читателям строго по возрастанию и служат
+-- токенами возобновления WatchOrders.
+CREATE TABLE order_changes (
+                        seq BIGSERIAL PRIMARY KEY,
+                        txid BIGINT NOT NULL DEFAULT pg_current_xact_id()::text::bigint,
+                        order_id VARCHAR(36) NOT NULL,
+                        change_type VARCHAR(16) NOT NULL,
+                        changed_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_order_changes_changed_at ON order_changes(changed_at);
+CREATE INDEX idx_order_changes_position ON order_changes(txid, seq);
+
+-- позиция последней удалённой очисткой записи: токены не старше неё уже не возобновить
+CREATE TABLE order_changes_purged (
+                        id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+                        txid BIGINT NOT NULL,
+                        seq BIGINT NOT NULL
+);
+
+-- один вызов на оператор: COPY и пакетные INSERT пишут журнал одним запросом
+-- и шлют одно уведомление. Уведомление только будит читателей, изменения они
+-- берут из журнала.
+CREATE FUNCTION notify_order_changes() RETURNS trigger AS $$
+DECLARE
+    written BIGINT;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO order_changes (order_id, change_type)
+        SELECT id, 'CREATED' FROM new_orders;
+    ELSIF TG_OP = 'UPDATE' THEN
+        INSERT INTO order_changes (order_id, change_type)
+        SELECT n.id,
+               CASE WHEN n.deleted_at IS NOT NULL AND o.deleted_at IS NULL THEN 'DELETED' ELSE 'UPDATED' END
+        FROM new_orders n
+        JOIN old_orders o ON o.id = n.id;
+    ELSE
+        -- окончательная очистка уже удалённого заказа отдельным событием не считается
+        INSERT INTO order_changes (order_id, change_type)
+        SELECT id, 'DELETED' FROM old_orders WHERE deleted_at IS NULL;
+    END IF;
+
+    GET DIAGNOSTICS written = ROW_COUNT;
+    IF written > 0 THEN
+        PERFORM pg_notify('order_changes', '');
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER orders_notify_insert
+    AFTER INSERT ON orders
+    REFERENCING NEW TABLE AS new_orders
+    FOR EACH STATEMENT EXECUTE FUNCTION notify_order_changes();
+
+CREATE TRIGGER orders_notify_update
+    AFTER UPDATE ON orders
+    REFERENCING OLD TABLE AS old_orders NEW TABLE AS new_orders
+    FOR EACH STATEMENT EXECUTE FUNCTION notify_order_changes();
+
+CREATE TRIGGER orders_notify_delete
+    AFTER DELETE ON orders
+    REFERENCING OLD TABLE AS old_orders
+    FOR EACH STATEMENT EXECUTE FUNCTION notify_order_changes();
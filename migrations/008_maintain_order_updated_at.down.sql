@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS orders_set_updated_at ON orders;
+
+DROP FUNCTION IF EXISTS set_updated_at();
+
+DROP INDEX IF EXISTS idx_orders_updated_at;
+
+ALTER TABLE orders
+    ALTER COLUMN created_at DROP NOT NULL,
+    ALTER COLUMN updated_at DROP NOT NULL;
@@ -0,0 +1,22 @@
+UPDATE orders SET created_at = NOW() WHERE created_at IS NULL;
+
+UPDATE orders SET updated_at = created_at WHERE updated_at IS NULL;
+
+ALTER TABLE orders
+    ALTER COLUMN created_at SET NOT NULL,
+    ALTER COLUMN updated_at SET NOT NULL;
+
+CREATE INDEX idx_orders_updated_at ON orders(updated_at);
+
+-- updated_at обновляется при любом изменении строки заказа, в том числе при замене
+-- позиций: репозиторий в этом случае всё равно поднимает version
+CREATE FUNCTION set_updated_at() RETURNS trigger AS $$
+BEGIN
+    NEW.updated_at := NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER orders_set_updated_at
+    BEFORE UPDATE ON orders
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
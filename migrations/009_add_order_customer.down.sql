@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_orders_customer_created;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS customer_id;
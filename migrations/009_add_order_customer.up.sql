@@ -0,0 +1,5 @@
+-- заказы, созданные до появления владельца, остаются с пустым customer_id
+ALTER TABLE orders ADD COLUMN customer_id VARCHAR(64) NOT NULL DEFAULT '';
+
+-- под ListCustomerOrders с сортировкой по умолчанию (create_time, id)
+CREATE INDEX idx_orders_customer_created ON orders(customer_id, created_at, id);
@@ -0,0 +1,9 @@
+ALTER TABLE orders
+    DROP CONSTRAINT IF EXISTS orders_total_check,
+    DROP COLUMN IF EXISTS total_minor,
+    DROP COLUMN IF EXISTS currency;
+
+ALTER TABLE order_items
+    DROP CONSTRAINT IF EXISTS order_items_price_check,
+    DROP COLUMN IF EXISTS unit_price_minor,
+    DROP COLUMN IF EXISTS currency;
@@ -0,0 +1,10 @@
+-- суммы хранятся в минорных единицах валюты (центах, копейках), без плавающей точки
+ALTER TABLE order_items
+    ADD COLUMN unit_price_minor BIGINT,
+    ADD COLUMN currency CHAR(3),
+    ADD CONSTRAINT order_items_price_check CHECK ((unit_price_minor IS NULL) = (currency IS NULL));
+
+ALTER TABLE orders
+    ADD COLUMN total_minor BIGINT,
+    ADD COLUMN currency CHAR(3),
+    ADD CONSTRAINT orders_total_check CHECK ((total_minor IS NULL) = (currency IS NULL));
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_order_items_search;
+
+ALTER TABLE order_items
+    DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,6 @@
+-- поиск по названиям позиций: конфигурация simple без стемминга, чтобы префиксы
+-- работали одинаково для любых языков и артикулов
+ALTER TABLE order_items
+    ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (to_tsvector('simple', item)) STORED;
+
+CREATE INDEX idx_order_items_search ON order_items USING GIN (search_vector);
@@ -0,0 +1,15 @@
+-- аудит изменений заказов: пишется в той же транзакции, что и само изменение.
+-- id задаёт порядок: изменения одного заказа идут под блокировкой его строки,
+-- так что более позднее изменение всегда получает больший id.
+-- Внешнего ключа на orders нет: история остаётся после очистки заказа
+CREATE TABLE order_events (
+                        id BIGSERIAL PRIMARY KEY,
+                        order_id VARCHAR(36) NOT NULL,
+                        action VARCHAR(32) NOT NULL,
+                        actor VARCHAR(255) NOT NULL DEFAULT '',
+                        old_value JSONB,
+                        new_value JSONB,
+                        created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_order_events_order ON order_events(order_id, id);
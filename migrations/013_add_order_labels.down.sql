@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_orders_labels;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS labels;
@@ -0,0 +1,4 @@
+ALTER TABLE orders ADD COLUMN labels JSONB NOT NULL DEFAULT '{}';
+
+-- jsonb_ops, а не jsonb_path_ops: селекторы проверяют и вхождение (@>), и наличие ключа (?)
+CREATE INDEX idx_orders_labels ON orders USING GIN (labels);
@@ -0,0 +1,22 @@
+-- остатки отслеживаемых позиций; позиций без записи здесь заказы не ограничивают
+CREATE TABLE inventory (
+                        item VARCHAR(255) PRIMARY KEY,
+                        available BIGINT NOT NULL DEFAULT 0 CHECK (available >= 0),
+                        created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                        updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER inventory_set_updated_at
+    BEFORE UPDATE ON inventory
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+-- сколько каждый открытый заказ держит из остатка позиции. Резерв снимается отменой
+-- (возвращается в available) или отгрузкой (списывается)
+CREATE TABLE inventory_reservations (
+                        order_id VARCHAR(36) NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+                        item VARCHAR(255) NOT NULL REFERENCES inventory(item),
+                        quantity BIGINT NOT NULL CHECK (quantity > 0),
+                        PRIMARY KEY (order_id, item)
+);
+
+CREATE INDEX idx_inventory_reservations_item ON inventory_reservations(item);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_orders_expire_at;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS expire_at;
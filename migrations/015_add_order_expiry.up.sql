@@ -0,0 +1,5 @@
+-- срок, до которого заказ в PENDING должен быть подтверждён; NULL - не истекает
+ALTER TABLE orders ADD COLUMN expire_at TIMESTAMP;
+
+-- по нему фоновая задача ищет просроченные неподтверждённые заказы
+CREATE INDEX idx_orders_expire_at ON orders(expire_at) WHERE status = 'PENDING' AND deleted_at IS NULL;
@@ -7,9 +7,13 @@
 package test
 
 import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
+	money "google.golang.org/genproto/googleapis/type/money"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -22,30 +26,2980 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type OrderStatus int32
+
+const (
+	OrderStatus_ORDER_STATUS_UNSPECIFIED OrderStatus = 0
+	OrderStatus_ORDER_STATUS_PENDING     OrderStatus = 1
+	OrderStatus_ORDER_STATUS_CONFIRMED   OrderStatus = 2
+	OrderStatus_ORDER_STATUS_PAID        OrderStatus = 3
+	OrderStatus_ORDER_STATUS_SHIPPED     OrderStatus = 4
+	OrderStatus_ORDER_STATUS_DELIVERED   OrderStatus = 5
+	OrderStatus_ORDER_STATUS_CANCELLED   OrderStatus = 6
+)
+
+// Enum value maps for OrderStatus.
+var (
+	OrderStatus_name = map[int32]string{
+		0: "ORDER_STATUS_UNSPECIFIED",
+		1: "ORDER_STATUS_PENDING",
+		2: "ORDER_STATUS_CONFIRMED",
+		3: "ORDER_STATUS_PAID",
+		4: "ORDER_STATUS_SHIPPED",
+		5: "ORDER_STATUS_DELIVERED",
+		6: "ORDER_STATUS_CANCELLED",
+	}
+	OrderStatus_value = map[string]int32{
+		"ORDER_STATUS_UNSPECIFIED": 0,
+		"ORDER_STATUS_PENDING":     1,
+		"ORDER_STATUS_CONFIRMED":   2,
+		"ORDER_STATUS_PAID":        3,
+		"ORDER_STATUS_SHIPPED":     4,
+		"ORDER_STATUS_DELIVERED":   5,
+		"ORDER_STATUS_CANCELLED":   6,
+	}
+)
+
+func (x OrderStatus) Enum() *OrderStatus {
+	p := new(OrderStatus)
+	*p = x
+	return p
+}
+
+func (x OrderStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_order_proto_enumTypes[0].Descriptor()
+}
+
+func (OrderStatus) Type() protoreflect.EnumType {
+	return &file_api_order_proto_enumTypes[0]
+}
+
+func (x OrderStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderStatus.Descriptor instead.
+func (OrderStatus) EnumDescriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{0}
+}
+
+type OrderHistoryAction int32
+
+const (
+	OrderHistoryAction_ORDER_HISTORY_ACTION_UNSPECIFIED    OrderHistoryAction = 0
+	OrderHistoryAction_ORDER_HISTORY_ACTION_CREATED        OrderHistoryAction = 1
+	OrderHistoryAction_ORDER_HISTORY_ACTION_UPDATED        OrderHistoryAction = 2
+	OrderHistoryAction_ORDER_HISTORY_ACTION_DELETED        OrderHistoryAction = 3
+	OrderHistoryAction_ORDER_HISTORY_ACTION_UNDELETED      OrderHistoryAction = 4
+	OrderHistoryAction_ORDER_HISTORY_ACTION_STATUS_CHANGED OrderHistoryAction = 5
+	// The deleted order was removed for good; new_order is unset.
+	OrderHistoryAction_ORDER_HISTORY_ACTION_PURGED OrderHistoryAction = 6
+)
+
+// Enum value maps for OrderHistoryAction.
+var (
+	OrderHistoryAction_name = map[int32]string{
+		0: "ORDER_HISTORY_ACTION_UNSPECIFIED",
+		1: "ORDER_HISTORY_ACTION_CREATED",
+		2: "ORDER_HISTORY_ACTION_UPDATED",
+		3: "ORDER_HISTORY_ACTION_DELETED",
+		4: "ORDER_HISTORY_ACTION_UNDELETED",
+		5: "ORDER_HISTORY_ACTION_STATUS_CHANGED",
+		6: "ORDER_HISTORY_ACTION_PURGED",
+	}
+	OrderHistoryAction_value = map[string]int32{
+		"ORDER_HISTORY_ACTION_UNSPECIFIED":    0,
+		"ORDER_HISTORY_ACTION_CREATED":        1,
+		"ORDER_HISTORY_ACTION_UPDATED":        2,
+		"ORDER_HISTORY_ACTION_DELETED":        3,
+		"ORDER_HISTORY_ACTION_UNDELETED":      4,
+		"ORDER_HISTORY_ACTION_STATUS_CHANGED": 5,
+		"ORDER_HISTORY_ACTION_PURGED":         6,
+	}
+)
+
+func (x OrderHistoryAction) Enum() *OrderHistoryAction {
+	p := new(OrderHistoryAction)
+	*p = x
+	return p
+}
+
+func (x OrderHistoryAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderHistoryAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_order_proto_enumTypes[1].Descriptor()
+}
+
+func (OrderHistoryAction) Type() protoreflect.EnumType {
+	return &file_api_order_proto_enumTypes[1]
+}
+
+func (x OrderHistoryAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderHistoryAction.Descriptor instead.
+func (OrderHistoryAction) EnumDescriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{1}
+}
+
+type OrderEventType int32
+
+const (
+	OrderEventType_ORDER_EVENT_TYPE_UNSPECIFIED OrderEventType = 0
+	OrderEventType_ORDER_EVENT_TYPE_CREATED     OrderEventType = 1
+	OrderEventType_ORDER_EVENT_TYPE_UPDATED     OrderEventType = 2
+	OrderEventType_ORDER_EVENT_TYPE_DELETED     OrderEventType = 3
+)
+
+// Enum value maps for OrderEventType.
+var (
+	OrderEventType_name = map[int32]string{
+		0: "ORDER_EVENT_TYPE_UNSPECIFIED",
+		1: "ORDER_EVENT_TYPE_CREATED",
+		2: "ORDER_EVENT_TYPE_UPDATED",
+		3: "ORDER_EVENT_TYPE_DELETED",
+	}
+	OrderEventType_value = map[string]int32{
+		"ORDER_EVENT_TYPE_UNSPECIFIED": 0,
+		"ORDER_EVENT_TYPE_CREATED":     1,
+		"ORDER_EVENT_TYPE_UPDATED":     2,
+		"ORDER_EVENT_TYPE_DELETED":     3,
+	}
+)
+
+func (x OrderEventType) Enum() *OrderEventType {
+	p := new(OrderEventType)
+	*p = x
+	return p
+}
+
+func (x OrderEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_order_proto_enumTypes[2].Descriptor()
+}
+
+func (OrderEventType) Type() protoreflect.EnumType {
+	return &file_api_order_proto_enumTypes[2]
+}
+
+func (x OrderEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderEventType.Descriptor instead.
+func (OrderEventType) EnumDescriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{2}
+}
+
+type LineItem struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Item     string                 `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	Quantity int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// Price of one unit. Either every line of an order has a price or none does,
+	// and all prices of an order share one currency.
+	UnitPrice     *money.Money `protobuf:"bytes,3,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LineItem) Reset() {
+	*x = LineItem{}
+	mi := &file_api_order_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LineItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LineItem) ProtoMessage() {}
+
+func (x *LineItem) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LineItem.ProtoReflect.Descriptor instead.
+func (*LineItem) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LineItem) GetItem() string {
+	if x != nil {
+		return x.Item
+	}
+	return ""
+}
+
+func (x *LineItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *LineItem) GetUnitPrice() *money.Money {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return nil
+}
+
+// Validation rules apply where Order is a request field (UpdateOrder).
 type Order struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Item          string                 `protobuf:"bytes,2,opt,name=item,proto3" json:"item,omitempty"`
-	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// item and quantity summarise line_items (first item and total quantity)
+	// for clients that predate multi-line orders.
+	Item      string      `protobuf:"bytes,2,opt,name=item,proto3" json:"item,omitempty"`
+	Quantity  int32       `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Status    OrderStatus `protobuf:"varint,4,opt,name=status,proto3,enum=api.OrderStatus" json:"status,omitempty"`
+	LineItems []*LineItem `protobuf:"bytes,5,rep,name=line_items,json=lineItems,proto3" json:"line_items,omitempty"`
+	// Incremented on every change of the order.
+	Version int64 `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
+	// Opaque version tag. Pass it back in UpdateOrder/DeleteOrder (or as the
+	// If-Match header through the gateway) to fail with ABORTED instead of
+	// overwriting a concurrent change.
+	Etag string `protobuf:"bytes,7,opt,name=etag,proto3" json:"etag,omitempty"`
+	// Set when the order is soft-deleted; it is purged after the retention period.
+	DeleteTime *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=delete_time,json=deleteTime,proto3" json:"delete_time,omitempty"`
+	// Output only. Usable in ListOrders filter and order_by.
+	CreateTime *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	// Owner of the order; empty for orders created before ownership existed.
+	// Set on creation and cannot be changed.
+	CustomerId string `protobuf:"bytes,11,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	// Output only. Sum of unit_price * quantity over line_items; empty when the
+	// lines have no prices.
+	Total *money.Money `protobuf:"bytes,12,opt,name=total,proto3" json:"total,omitempty"`
+	// Free-form tags such as channel, campaign or region. Keys and values follow
+	// Kubernetes label syntax; select orders by them with label_selector in ListOrders.
+	Labels map[string]string `protobuf:"bytes,13,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Output only. When a PENDING order is cancelled automatically unless it is
+	// confirmed first. Empty once the order leaves PENDING, for imported orders
+	// and when expiry is disabled on the server.
+	ExpireTime    *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Order) Reset() {
 	*x = Order{}
-	mi := &file_api_order_proto_msgTypes[0]
+	mi := &file_api_order_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Order) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Order) ProtoMessage() {}
+
+func (x *Order) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Order.ProtoReflect.Descriptor instead.
+func (*Order) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Order) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Order) GetItem() string {
+	if x != nil {
+		return x.Item
+	}
+	return ""
+}
+
+func (x *Order) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Order) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *Order) GetLineItems() []*LineItem {
+	if x != nil {
+		return x.LineItems
+	}
+	return nil
+}
+
+func (x *Order) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Order) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *Order) GetDeleteTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeleteTime
+	}
+	return nil
+}
+
+func (x *Order) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *Order) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+func (x *Order) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *Order) GetTotal() *money.Money {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+func (x *Order) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Order) GetExpireTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return nil
+}
+
+type CreateOrderRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Used as a single line when line_items is empty; then both item and a
+	// positive quantity are required.
+	Item      string      `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	Quantity  int32       `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	LineItems []*LineItem `protobuf:"bytes,3,rep,name=line_items,json=lineItems,proto3" json:"line_items,omitempty"`
+	// Idempotency key chosen by the client (the Idempotency-Key header through
+	// the gateway). Retrying with the same key returns the original response;
+	// reusing it for a different request fails with INVALID_ARGUMENT. Up to 255
+	// printable ASCII characters; must match the header if both are set.
+	RequestId  string `protobuf:"bytes,4,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	CustomerId string `protobuf:"bytes,5,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	// Client-chosen order id, accepted only when the server runs with
+	// ORDER_ID_STRATEGY=client; otherwise it must be empty. An id that is
+	// already taken fails with ALREADY_EXISTS.
+	OrderId string `protobuf:"bytes,6,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// Labels of the new order, see Order.labels.
+	Labels        map[string]string `protobuf:"bytes,7,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrderRequest) Reset() {
+	*x = CreateOrderRequest{}
+	mi := &file_api_order_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrderRequest) ProtoMessage() {}
+
+func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrderRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrderRequest) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateOrderRequest) GetItem() string {
+	if x != nil {
+		return x.Item
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *CreateOrderRequest) GetLineItems() []*LineItem {
+	if x != nil {
+		return x.LineItems
+	}
+	return nil
+}
+
+func (x *CreateOrderRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type CreateOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrderResponse) Reset() {
+	*x = CreateOrderResponse{}
+	mi := &file_api_order_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrderResponse) ProtoMessage() {}
+
+func (x *CreateOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrderResponse.ProtoReflect.Descriptor instead.
+func (*CreateOrderResponse) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateOrderResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderRequest) Reset() {
+	*x = GetOrderRequest{}
+	mi := &file_api_order_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderRequest) ProtoMessage() {}
+
+func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderRequest) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetOrderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// GetOrderHistory returns the audit trail of one order, oldest change first.
+// The history outlives the order: purging a deleted order appends a PURGED entry.
+type GetOrderHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Defaults to 50; values above 1000 are coerced to 1000.
+	PageSize      int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderHistoryRequest) Reset() {
+	*x = GetOrderHistoryRequest{}
+	mi := &file_api_order_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderHistoryRequest) ProtoMessage() {}
+
+func (x *GetOrderHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetOrderHistoryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetOrderHistoryRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetOrderHistoryRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type GetOrderHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*OrderHistoryEntry   `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderHistoryResponse) Reset() {
+	*x = GetOrderHistoryResponse{}
+	mi := &file_api_order_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderHistoryResponse) ProtoMessage() {}
+
+func (x *GetOrderHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetOrderHistoryResponse) GetEntries() []*OrderHistoryEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *GetOrderHistoryResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type OrderHistoryEntry struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Action OrderHistoryAction     `protobuf:"varint,1,opt,name=action,proto3,enum=api.OrderHistoryAction" json:"action,omitempty"`
+	// Who made the change, taken from the X-Actor header (x-actor metadata);
+	// empty when the caller did not say.
+	Actor      string                 `protobuf:"bytes,2,opt,name=actor,proto3" json:"actor,omitempty"`
+	ChangeTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=change_time,json=changeTime,proto3" json:"change_time,omitempty"`
+	// The order before the change; unset for CREATED.
+	OldOrder *Order `protobuf:"bytes,4,opt,name=old_order,json=oldOrder,proto3" json:"old_order,omitempty"`
+	// The order after the change; unset for PURGED.
+	NewOrder      *Order `protobuf:"bytes,5,opt,name=new_order,json=newOrder,proto3" json:"new_order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderHistoryEntry) Reset() {
+	*x = OrderHistoryEntry{}
+	mi := &file_api_order_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderHistoryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderHistoryEntry) ProtoMessage() {}
+
+func (x *OrderHistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderHistoryEntry.ProtoReflect.Descriptor instead.
+func (*OrderHistoryEntry) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *OrderHistoryEntry) GetAction() OrderHistoryAction {
+	if x != nil {
+		return x.Action
+	}
+	return OrderHistoryAction_ORDER_HISTORY_ACTION_UNSPECIFIED
+}
+
+func (x *OrderHistoryEntry) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *OrderHistoryEntry) GetChangeTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChangeTime
+	}
+	return nil
+}
+
+func (x *OrderHistoryEntry) GetOldOrder() *Order {
+	if x != nil {
+		return x.OldOrder
+	}
+	return nil
+}
+
+func (x *OrderHistoryEntry) GetNewOrder() *Order {
+	if x != nil {
+		return x.NewOrder
+	}
+	return nil
+}
+
+type GetOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderResponse) Reset() {
+	*x = GetOrderResponse{}
+	mi := &file_api_order_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderResponse) ProtoMessage() {}
+
+func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderResponse) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type UpdateOrderRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// order.id selects the order to update. order.etag, if set, must match
+	// the stored order.
+	Order *Order `protobuf:"bytes,5,opt,name=order,proto3" json:"order,omitempty"`
+	// Fields of order to overwrite: item, quantity, line_items, labels or "*".
+	// "*" replaces the order with what order carries: its line_items (or a single
+	// line from item and quantity) and its labels. When empty, every field set in
+	// order is updated.
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,6,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrderRequest) Reset() {
+	*x = UpdateOrderRequest{}
+	mi := &file_api_order_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrderRequest) ProtoMessage() {}
+
+func (x *UpdateOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrderRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOrderRequest) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateOrderRequest) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *UpdateOrderRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type UpdateOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrderResponse) Reset() {
+	*x = UpdateOrderResponse{}
+	mi := &file_api_order_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrderResponse) ProtoMessage() {}
+
+func (x *UpdateOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrderResponse.ProtoReflect.Descriptor instead.
+func (*UpdateOrderResponse) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type DeleteOrderRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Optional etag of the order; the delete is aborted if it is stale.
+	Etag          string `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteOrderRequest) Reset() {
+	*x = DeleteOrderRequest{}
+	mi := &file_api_order_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOrderRequest) ProtoMessage() {}
+
+func (x *DeleteOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOrderRequest.ProtoReflect.Descriptor instead.
+func (*DeleteOrderRequest) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeleteOrderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteOrderRequest) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+type DeleteOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteOrderResponse) Reset() {
+	*x = DeleteOrderResponse{}
+	mi := &file_api_order_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOrderResponse) ProtoMessage() {}
+
+func (x *DeleteOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOrderResponse.ProtoReflect.Descriptor instead.
+func (*DeleteOrderResponse) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeleteOrderResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// Batch RPCs are all-or-nothing: if any item fails, nothing is written and the
+// error details name the failing items.
+type BatchCreateOrdersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// request_id is not supported inside a batch.
+	Requests      []*CreateOrderRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchCreateOrdersRequest) Reset() {
+	*x = BatchCreateOrdersRequest{}
+	mi := &file_api_order_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchCreateOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCreateOrdersRequest) ProtoMessage() {}
+
+func (x *BatchCreateOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCreateOrdersRequest.ProtoReflect.Descriptor instead.
+func (*BatchCreateOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *BatchCreateOrdersRequest) GetRequests() []*CreateOrderRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+type BatchCreateOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchCreateOrdersResponse) Reset() {
+	*x = BatchCreateOrdersResponse{}
+	mi := &file_api_order_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchCreateOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCreateOrdersResponse) ProtoMessage() {}
+
+func (x *BatchCreateOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCreateOrdersResponse.ProtoReflect.Descriptor instead.
+func (*BatchCreateOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *BatchCreateOrdersResponse) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+type BatchGetOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetOrdersRequest) Reset() {
+	*x = BatchGetOrdersRequest{}
+	mi := &file_api_order_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetOrdersRequest) ProtoMessage() {}
+
+func (x *BatchGetOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetOrdersRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *BatchGetOrdersRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type BatchGetOrdersResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// In the same order as the requested ids.
+	Orders        []*Order `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetOrdersResponse) Reset() {
+	*x = BatchGetOrdersResponse{}
+	mi := &file_api_order_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetOrdersResponse) ProtoMessage() {}
+
+func (x *BatchGetOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetOrdersResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *BatchGetOrdersResponse) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+// ImportOrders is not all-or-nothing: invalid records are skipped and reported
+// in the summary, valid ones are written in batches of 1000. A batch that fails
+// stops the stream, but the batches before it stay written: the error message and
+// the "imported" ErrorInfo metadata say how many orders were imported. Orders in
+// PENDING, CONFIRMED or PAID reserve stock like CreateOrder; a batch that runs out
+// of stock fails the stream with RESOURCE_EXHAUSTED.
+type ImportOrdersRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Item       string                 `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	Quantity   int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	LineItems  []*LineItem            `protobuf:"bytes,3,rep,name=line_items,json=lineItems,proto3" json:"line_items,omitempty"`
+	CustomerId string                 `protobuf:"bytes,4,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	// Same as CreateOrderRequest.order_id. A taken id fails the whole stream
+	// with ALREADY_EXISTS, like any other database error.
+	OrderId string            `protobuf:"bytes,5,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Labels  map[string]string `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Status of the imported order; PENDING when unset. Imported orders never expire.
+	Status        OrderStatus `protobuf:"varint,7,opt,name=status,proto3,enum=api.OrderStatus" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportOrdersRequest) Reset() {
+	*x = ImportOrdersRequest{}
+	mi := &file_api_order_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportOrdersRequest) ProtoMessage() {}
+
+func (x *ImportOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportOrdersRequest.ProtoReflect.Descriptor instead.
+func (*ImportOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ImportOrdersRequest) GetItem() string {
+	if x != nil {
+		return x.Item
+	}
+	return ""
+}
+
+func (x *ImportOrdersRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ImportOrdersRequest) GetLineItems() []*LineItem {
+	if x != nil {
+		return x.LineItems
+	}
+	return nil
+}
+
+func (x *ImportOrdersRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *ImportOrdersRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ImportOrdersRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *ImportOrdersRequest) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+type ImportFailure struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Zero-based position of the record in the request stream.
+	Index         int64  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Field         string `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
+	Description   string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportFailure) Reset() {
+	*x = ImportFailure{}
+	mi := &file_api_order_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportFailure) ProtoMessage() {}
+
+func (x *ImportFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportFailure.ProtoReflect.Descriptor instead.
+func (*ImportFailure) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ImportFailure) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *ImportFailure) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *ImportFailure) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type ImportOrdersResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Received int64                  `protobuf:"varint,1,opt,name=received,proto3" json:"received,omitempty"`
+	Imported int64                  `protobuf:"varint,2,opt,name=imported,proto3" json:"imported,omitempty"`
+	Failed   int64                  `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	// At most the first 1000 failures; failed counts all of them.
+	Failures      []*ImportFailure `protobuf:"bytes,4,rep,name=failures,proto3" json:"failures,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportOrdersResponse) Reset() {
+	*x = ImportOrdersResponse{}
+	mi := &file_api_order_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportOrdersResponse) ProtoMessage() {}
+
+func (x *ImportOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportOrdersResponse.ProtoReflect.Descriptor instead.
+func (*ImportOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ImportOrdersResponse) GetReceived() int64 {
+	if x != nil {
+		return x.Received
+	}
+	return 0
+}
+
+func (x *ImportOrdersResponse) GetImported() int64 {
+	if x != nil {
+		return x.Imported
+	}
+	return 0
+}
+
+func (x *ImportOrdersResponse) GetFailed() int64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *ImportOrdersResponse) GetFailures() []*ImportFailure {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+type BatchDeleteOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchDeleteOrdersRequest) Reset() {
+	*x = BatchDeleteOrdersRequest{}
+	mi := &file_api_order_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchDeleteOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchDeleteOrdersRequest) ProtoMessage() {}
+
+func (x *BatchDeleteOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchDeleteOrdersRequest.ProtoReflect.Descriptor instead.
+func (*BatchDeleteOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *BatchDeleteOrdersRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type BatchDeleteOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchDeleteOrdersResponse) Reset() {
+	*x = BatchDeleteOrdersResponse{}
+	mi := &file_api_order_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchDeleteOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchDeleteOrdersResponse) ProtoMessage() {}
+
+func (x *BatchDeleteOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchDeleteOrdersResponse.ProtoReflect.Descriptor instead.
+func (*BatchDeleteOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *BatchDeleteOrdersResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type UndeleteOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UndeleteOrderRequest) Reset() {
+	*x = UndeleteOrderRequest{}
+	mi := &file_api_order_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UndeleteOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UndeleteOrderRequest) ProtoMessage() {}
+
+func (x *UndeleteOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UndeleteOrderRequest.ProtoReflect.Descriptor instead.
+func (*UndeleteOrderRequest) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *UndeleteOrderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type UndeleteOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UndeleteOrderResponse) Reset() {
+	*x = UndeleteOrderResponse{}
+	mi := &file_api_order_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UndeleteOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UndeleteOrderResponse) ProtoMessage() {}
+
+func (x *UndeleteOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UndeleteOrderResponse.ProtoReflect.Descriptor instead.
+func (*UndeleteOrderResponse) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *UndeleteOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type ListOrdersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Defaults to 50; values above 1000 are coerced to 1000.
+	PageSize  int32  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// AIP-160 filter, e.g. `item:"lap" AND quantity >= 2 AND create_time > "2025-01-01T00:00:00Z"`.
+	Filter string `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Comma separated fields with optional `desc`, e.g. `quantity desc, create_time`.
+	OrderBy string `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	// Include soft-deleted orders.
+	ShowDeleted bool `protobuf:"varint,5,opt,name=show_deleted,json=showDeleted,proto3" json:"show_deleted,omitempty"`
+	// Kubernetes-style label selector, e.g. `channel=web,region in (eu,us),!internal`.
+	// Requirements are ANDed together and with filter.
+	LabelSelector string `protobuf:"bytes,6,opt,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersRequest) Reset() {
+	*x = ListOrdersRequest{}
+	mi := &file_api_order_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersRequest) ProtoMessage() {}
+
+func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersRequest.ProtoReflect.Descriptor instead.
+func (*ListOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListOrdersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListOrdersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetShowDeleted() bool {
+	if x != nil {
+		return x.ShowDeleted
+	}
+	return false
+}
+
+func (x *ListOrdersRequest) GetLabelSelector() string {
+	if x != nil {
+		return x.LabelSelector
+	}
+	return ""
+}
+
+type ListOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersResponse) Reset() {
+	*x = ListOrdersResponse{}
+	mi := &file_api_order_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersResponse) ProtoMessage() {}
+
+func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersResponse.ProtoReflect.Descriptor instead.
+func (*ListOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListOrdersResponse) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *ListOrdersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Same as ListOrders, restricted to the orders of one customer.
+type ListCustomerOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Filter        string                 `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`
+	OrderBy       string                 `protobuf:"bytes,5,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	ShowDeleted   bool                   `protobuf:"varint,6,opt,name=show_deleted,json=showDeleted,proto3" json:"show_deleted,omitempty"`
+	LabelSelector string                 `protobuf:"bytes,7,opt,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCustomerOrdersRequest) Reset() {
+	*x = ListCustomerOrdersRequest{}
+	mi := &file_api_order_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCustomerOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCustomerOrdersRequest) ProtoMessage() {}
+
+func (x *ListCustomerOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCustomerOrdersRequest.ProtoReflect.Descriptor instead.
+func (*ListCustomerOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListCustomerOrdersRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *ListCustomerOrdersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListCustomerOrdersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListCustomerOrdersRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *ListCustomerOrdersRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListCustomerOrdersRequest) GetShowDeleted() bool {
+	if x != nil {
+		return x.ShowDeleted
+	}
+	return false
+}
+
+func (x *ListCustomerOrdersRequest) GetLabelSelector() string {
+	if x != nil {
+		return x.LabelSelector
+	}
+	return ""
+}
+
+type ListCustomerOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCustomerOrdersResponse) Reset() {
+	*x = ListCustomerOrdersResponse{}
+	mi := &file_api_order_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCustomerOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCustomerOrdersResponse) ProtoMessage() {}
+
+func (x *ListCustomerOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCustomerOrdersResponse.ProtoReflect.Descriptor instead.
+func (*ListCustomerOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListCustomerOrdersResponse) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *ListCustomerOrdersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ConfirmOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmOrderRequest) Reset() {
+	*x = ConfirmOrderRequest{}
+	mi := &file_api_order_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmOrderRequest) ProtoMessage() {}
+
+func (x *ConfirmOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmOrderRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmOrderRequest) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ConfirmOrderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ConfirmOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmOrderResponse) Reset() {
+	*x = ConfirmOrderResponse{}
+	mi := &file_api_order_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmOrderResponse) ProtoMessage() {}
+
+func (x *ConfirmOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmOrderResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmOrderResponse) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ConfirmOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type PayOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PayOrderRequest) Reset() {
+	*x = PayOrderRequest{}
+	mi := &file_api_order_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PayOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PayOrderRequest) ProtoMessage() {}
+
+func (x *PayOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PayOrderRequest.ProtoReflect.Descriptor instead.
+func (*PayOrderRequest) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *PayOrderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type PayOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PayOrderResponse) Reset() {
+	*x = PayOrderResponse{}
+	mi := &file_api_order_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PayOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PayOrderResponse) ProtoMessage() {}
+
+func (x *PayOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PayOrderResponse.ProtoReflect.Descriptor instead.
+func (*PayOrderResponse) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *PayOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type ShipOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShipOrderRequest) Reset() {
+	*x = ShipOrderRequest{}
+	mi := &file_api_order_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShipOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShipOrderRequest) ProtoMessage() {}
+
+func (x *ShipOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShipOrderRequest.ProtoReflect.Descriptor instead.
+func (*ShipOrderRequest) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ShipOrderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ShipOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShipOrderResponse) Reset() {
+	*x = ShipOrderResponse{}
+	mi := &file_api_order_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShipOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShipOrderResponse) ProtoMessage() {}
+
+func (x *ShipOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShipOrderResponse.ProtoReflect.Descriptor instead.
+func (*ShipOrderResponse) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ShipOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type DeliverOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeliverOrderRequest) Reset() {
+	*x = DeliverOrderRequest{}
+	mi := &file_api_order_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeliverOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliverOrderRequest) ProtoMessage() {}
+
+func (x *DeliverOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliverOrderRequest.ProtoReflect.Descriptor instead.
+func (*DeliverOrderRequest) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *DeliverOrderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeliverOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeliverOrderResponse) Reset() {
+	*x = DeliverOrderResponse{}
+	mi := &file_api_order_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeliverOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliverOrderResponse) ProtoMessage() {}
+
+func (x *DeliverOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliverOrderResponse.ProtoReflect.Descriptor instead.
+func (*DeliverOrderResponse) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DeliverOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type CancelOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderRequest) Reset() {
+	*x = CancelOrderRequest{}
+	mi := &file_api_order_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderRequest) ProtoMessage() {}
+
+func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
+func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *CancelOrderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CancelOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderResponse) Reset() {
+	*x = CancelOrderResponse{}
+	mi := &file_api_order_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderResponse) ProtoMessage() {}
+
+func (x *CancelOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderResponse.ProtoReflect.Descriptor instead.
+func (*CancelOrderResponse) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *CancelOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+// StreamOrders sends every matching order without paging, for exports.
+type StreamOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        string                 `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	OrderBy       string                 `protobuf:"bytes,2,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	ShowDeleted   bool                   `protobuf:"varint,3,opt,name=show_deleted,json=showDeleted,proto3" json:"show_deleted,omitempty"`
+	LabelSelector string                 `protobuf:"bytes,4,opt,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamOrdersRequest) Reset() {
+	*x = StreamOrdersRequest{}
+	mi := &file_api_order_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamOrdersRequest) ProtoMessage() {}
+
+func (x *StreamOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamOrdersRequest.ProtoReflect.Descriptor instead.
+func (*StreamOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *StreamOrdersRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *StreamOrdersRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *StreamOrdersRequest) GetShowDeleted() bool {
+	if x != nil {
+		return x.ShowDeleted
+	}
+	return false
+}
+
+func (x *StreamOrdersRequest) GetLabelSelector() string {
+	if x != nil {
+		return x.LabelSelector
+	}
+	return ""
+}
+
+type StreamOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamOrdersResponse) Reset() {
+	*x = StreamOrdersResponse{}
+	mi := &file_api_order_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamOrdersResponse) ProtoMessage() {}
+
+func (x *StreamOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamOrdersResponse.ProtoReflect.Descriptor instead.
+func (*StreamOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *StreamOrdersResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+// SearchOrders finds orders by words of their line item names, best matches first.
+type SearchOrdersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Search text. Every word must match the beginning of a word in the same item
+	// name of the order, e.g. `lap pro` matches "Laptop Pro 14".
+	Q string `protobuf:"bytes,1,opt,name=q,proto3" json:"q,omitempty"`
+	// Defaults to 50; values above 1000 are coerced to 1000.
+	PageSize  int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Include soft-deleted orders.
+	ShowDeleted   bool `protobuf:"varint,4,opt,name=show_deleted,json=showDeleted,proto3" json:"show_deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchOrdersRequest) Reset() {
+	*x = SearchOrdersRequest{}
+	mi := &file_api_order_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchOrdersRequest) ProtoMessage() {}
+
+func (x *SearchOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchOrdersRequest.ProtoReflect.Descriptor instead.
+func (*SearchOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *SearchOrdersRequest) GetQ() string {
+	if x != nil {
+		return x.Q
+	}
+	return ""
+}
+
+func (x *SearchOrdersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *SearchOrdersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *SearchOrdersRequest) GetShowDeleted() bool {
+	if x != nil {
+		return x.ShowDeleted
+	}
+	return false
+}
+
+type SearchOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchOrdersResponse) Reset() {
+	*x = SearchOrdersResponse{}
+	mi := &file_api_order_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchOrdersResponse) ProtoMessage() {}
+
+func (x *SearchOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchOrdersResponse.ProtoReflect.Descriptor instead.
+func (*SearchOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *SearchOrdersResponse) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *SearchOrdersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// GetOrderStats aggregates non-deleted orders created in [start_time, end_time).
+// Results are cached briefly and may lag behind the latest writes by up to a minute.
+type GetOrderStatsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// Must be after start_time; the range may span at most 366 days.
+	EndTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// How many of the most ordered items to return. Defaults to 100.
+	ItemLimit     int32 `protobuf:"varint,3,opt,name=item_limit,json=itemLimit,proto3" json:"item_limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderStatsRequest) Reset() {
+	*x = GetOrderStatsRequest{}
+	mi := &file_api_order_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderStatsRequest) ProtoMessage() {}
+
+func (x *GetOrderStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderStatsRequest) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *GetOrderStatsRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *GetOrderStatsRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *GetOrderStatsRequest) GetItemLimit() int32 {
+	if x != nil {
+		return x.ItemLimit
+	}
+	return 0
+}
+
+type GetOrderStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stats         *OrderStats            `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderStatsResponse) Reset() {
+	*x = GetOrderStatsResponse{}
+	mi := &file_api_order_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderStatsResponse) ProtoMessage() {}
+
+func (x *GetOrderStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderStatsResponse) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetOrderStatsResponse) GetStats() *OrderStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type OrderStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	TotalOrders   int64                  `protobuf:"varint,3,opt,name=total_orders,json=totalOrders,proto3" json:"total_orders,omitempty"`
+	TotalQuantity int64                  `protobuf:"varint,4,opt,name=total_quantity,json=totalQuantity,proto3" json:"total_quantity,omitempty"`
+	// Most ordered items, by quantity descending, then by name.
+	Items []*ItemStats `protobuf:"bytes,5,rep,name=items,proto3" json:"items,omitempty"`
+	// One bucket per UTC day overlapping the range, including days without orders.
+	Days          []*DayStats `protobuf:"bytes,6,rep,name=days,proto3" json:"days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderStats) Reset() {
+	*x = OrderStats{}
+	mi := &file_api_order_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderStats) ProtoMessage() {}
+
+func (x *OrderStats) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderStats.ProtoReflect.Descriptor instead.
+func (*OrderStats) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *OrderStats) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *OrderStats) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *OrderStats) GetTotalOrders() int64 {
+	if x != nil {
+		return x.TotalOrders
+	}
+	return 0
+}
+
+func (x *OrderStats) GetTotalQuantity() int64 {
+	if x != nil {
+		return x.TotalQuantity
+	}
+	return 0
+}
+
+func (x *OrderStats) GetItems() []*ItemStats {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *OrderStats) GetDays() []*DayStats {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+type ItemStats struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Item  string                 `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	// Number of orders with this item.
+	Orders        int64 `protobuf:"varint,2,opt,name=orders,proto3" json:"orders,omitempty"`
+	Quantity      int64 `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ItemStats) Reset() {
+	*x = ItemStats{}
+	mi := &file_api_order_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemStats) ProtoMessage() {}
+
+func (x *ItemStats) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemStats.ProtoReflect.Descriptor instead.
+func (*ItemStats) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ItemStats) GetItem() string {
+	if x != nil {
+		return x.Item
+	}
+	return ""
+}
+
+func (x *ItemStats) GetOrders() int64 {
+	if x != nil {
+		return x.Orders
+	}
+	return 0
+}
+
+func (x *ItemStats) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type DayStats struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Midnight UTC of the day.
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	Orders        int64                  `protobuf:"varint,2,opt,name=orders,proto3" json:"orders,omitempty"`
+	Quantity      int64                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DayStats) Reset() {
+	*x = DayStats{}
+	mi := &file_api_order_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DayStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DayStats) ProtoMessage() {}
+
+func (x *DayStats) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DayStats.ProtoReflect.Descriptor instead.
+func (*DayStats) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *DayStats) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *DayStats) GetOrders() int64 {
+	if x != nil {
+		return x.Orders
+	}
+	return 0
+}
+
+func (x *DayStats) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type WatchOrdersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// resume_token of the last event the client has seen. Events after it are
+	// replayed before live ones; empty means live events only. Events are
+	// delivered in commit order, so nothing committed before the token's event
+	// is skipped. Expired tokens fail with OUT_OF_RANGE.
+	ResumeToken   string `protobuf:"bytes,1,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchOrdersRequest) Reset() {
+	*x = WatchOrdersRequest{}
+	mi := &file_api_order_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchOrdersRequest) ProtoMessage() {}
+
+func (x *WatchOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchOrdersRequest.ProtoReflect.Descriptor instead.
+func (*WatchOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *WatchOrdersRequest) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+type WatchOrdersResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Type    OrderEventType         `protobuf:"varint,1,opt,name=type,proto3,enum=api.OrderEventType" json:"type,omitempty"`
+	OrderId string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// Current state of the order; empty for DELETED events.
+	Order         *Order                 `protobuf:"bytes,3,opt,name=order,proto3" json:"order,omitempty"`
+	EventTime     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=event_time,json=eventTime,proto3" json:"event_time,omitempty"`
+	ResumeToken   string                 `protobuf:"bytes,5,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchOrdersResponse) Reset() {
+	*x = WatchOrdersResponse{}
+	mi := &file_api_order_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Order) String() string {
+func (x *WatchOrdersResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Order) ProtoMessage() {}
+func (*WatchOrdersResponse) ProtoMessage() {}
 
-func (x *Order) ProtoReflect() protoreflect.Message {
-	mi := &file_api_order_proto_msgTypes[0]
+func (x *WatchOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -56,55 +3010,73 @@ func (x *Order) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Order.ProtoReflect.Descriptor instead.
-func (*Order) Descriptor() ([]byte, []int) {
-	return file_api_order_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use WatchOrdersResponse.ProtoReflect.Descriptor instead.
+func (*WatchOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *Order) GetId() string {
+func (x *WatchOrdersResponse) GetType() OrderEventType {
 	if x != nil {
-		return x.Id
+		return x.Type
 	}
-	return ""
+	return OrderEventType_ORDER_EVENT_TYPE_UNSPECIFIED
 }
 
-func (x *Order) GetItem() string {
+func (x *WatchOrdersResponse) GetOrderId() string {
 	if x != nil {
-		return x.Item
+		return x.OrderId
 	}
 	return ""
 }
 
-func (x *Order) GetQuantity() int32 {
+func (x *WatchOrdersResponse) GetOrder() *Order {
 	if x != nil {
-		return x.Quantity
+		return x.Order
 	}
-	return 0
+	return nil
 }
 
-type CreateOrderRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Item          string                 `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
-	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+func (x *WatchOrdersResponse) GetEventTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EventTime
+	}
+	return nil
+}
+
+func (x *WatchOrdersResponse) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+type Stock struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Item  string                 `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	// Stock that new orders can reserve.
+	Available int64 `protobuf:"varint,2,opt,name=available,proto3" json:"available,omitempty"`
+	// Stock held by orders that are neither shipped nor cancelled yet.
+	Reserved      int64                  `protobuf:"varint,3,opt,name=reserved,proto3" json:"reserved,omitempty"`
+	UpdateTime    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateOrderRequest) Reset() {
-	*x = CreateOrderRequest{}
-	mi := &file_api_order_proto_msgTypes[1]
+func (x *Stock) Reset() {
+	*x = Stock{}
+	mi := &file_api_order_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateOrderRequest) String() string {
+func (x *Stock) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateOrderRequest) ProtoMessage() {}
+func (*Stock) ProtoMessage() {}
 
-func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_order_proto_msgTypes[1]
+func (x *Stock) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -115,91 +3087,61 @@ func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateOrderRequest.ProtoReflect.Descriptor instead.
-func (*CreateOrderRequest) Descriptor() ([]byte, []int) {
-	return file_api_order_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use Stock.ProtoReflect.Descriptor instead.
+func (*Stock) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *CreateOrderRequest) GetItem() string {
+func (x *Stock) GetItem() string {
 	if x != nil {
 		return x.Item
 	}
 	return ""
 }
 
-func (x *CreateOrderRequest) GetQuantity() int32 {
+func (x *Stock) GetAvailable() int64 {
 	if x != nil {
-		return x.Quantity
+		return x.Available
 	}
 	return 0
 }
 
-type CreateOrderResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *CreateOrderResponse) Reset() {
-	*x = CreateOrderResponse{}
-	mi := &file_api_order_proto_msgTypes[2]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *CreateOrderResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*CreateOrderResponse) ProtoMessage() {}
-
-func (x *CreateOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_order_proto_msgTypes[2]
+func (x *Stock) GetReserved() int64 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Reserved
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use CreateOrderResponse.ProtoReflect.Descriptor instead.
-func (*CreateOrderResponse) Descriptor() ([]byte, []int) {
-	return file_api_order_proto_rawDescGZIP(), []int{2}
+	return 0
 }
 
-func (x *CreateOrderResponse) GetId() string {
+func (x *Stock) GetUpdateTime() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Id
+		return x.UpdateTime
 	}
-	return ""
+	return nil
 }
 
-type GetOrderRequest struct {
+type GetStockRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Item          string                 `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOrderRequest) Reset() {
-	*x = GetOrderRequest{}
-	mi := &file_api_order_proto_msgTypes[3]
+func (x *GetStockRequest) Reset() {
+	*x = GetStockRequest{}
+	mi := &file_api_order_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOrderRequest) String() string {
+func (x *GetStockRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOrderRequest) ProtoMessage() {}
+func (*GetStockRequest) ProtoMessage() {}
 
-func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_order_proto_msgTypes[3]
+func (x *GetStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -210,40 +3152,40 @@ func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
-func (*GetOrderRequest) Descriptor() ([]byte, []int) {
-	return file_api_order_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use GetStockRequest.ProtoReflect.Descriptor instead.
+func (*GetStockRequest) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *GetOrderRequest) GetId() string {
+func (x *GetStockRequest) GetItem() string {
 	if x != nil {
-		return x.Id
+		return x.Item
 	}
 	return ""
 }
 
-type GetOrderResponse struct {
+type GetStockResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	Stock         *Stock                 `protobuf:"bytes,1,opt,name=stock,proto3" json:"stock,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOrderResponse) Reset() {
-	*x = GetOrderResponse{}
-	mi := &file_api_order_proto_msgTypes[4]
+func (x *GetStockResponse) Reset() {
+	*x = GetStockResponse{}
+	mi := &file_api_order_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOrderResponse) String() string {
+func (x *GetStockResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOrderResponse) ProtoMessage() {}
+func (*GetStockResponse) ProtoMessage() {}
 
-func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_order_proto_msgTypes[4]
+func (x *GetStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -254,42 +3196,42 @@ func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOrderResponse.ProtoReflect.Descriptor instead.
-func (*GetOrderResponse) Descriptor() ([]byte, []int) {
-	return file_api_order_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use GetStockResponse.ProtoReflect.Descriptor instead.
+func (*GetStockResponse) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *GetOrderResponse) GetOrder() *Order {
+func (x *GetStockResponse) GetStock() *Stock {
 	if x != nil {
-		return x.Order
+		return x.Stock
 	}
 	return nil
 }
 
-type UpdateOrderRequest struct {
+// Tracked items in item order.
+type ListStockRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Item          string                 `protobuf:"bytes,2,opt,name=item,proto3" json:"item,omitempty"`
-	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateOrderRequest) Reset() {
-	*x = UpdateOrderRequest{}
-	mi := &file_api_order_proto_msgTypes[5]
+func (x *ListStockRequest) Reset() {
+	*x = ListStockRequest{}
+	mi := &file_api_order_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateOrderRequest) String() string {
+func (x *ListStockRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateOrderRequest) ProtoMessage() {}
+func (*ListStockRequest) ProtoMessage() {}
 
-func (x *UpdateOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_order_proto_msgTypes[5]
+func (x *ListStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -300,54 +3242,48 @@ func (x *UpdateOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateOrderRequest.ProtoReflect.Descriptor instead.
-func (*UpdateOrderRequest) Descriptor() ([]byte, []int) {
-	return file_api_order_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ListStockRequest.ProtoReflect.Descriptor instead.
+func (*ListStockRequest) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{52}
 }
 
-func (x *UpdateOrderRequest) GetId() string {
+func (x *ListStockRequest) GetPageSize() int32 {
 	if x != nil {
-		return x.Id
+		return x.PageSize
 	}
-	return ""
+	return 0
 }
 
-func (x *UpdateOrderRequest) GetItem() string {
+func (x *ListStockRequest) GetPageToken() string {
 	if x != nil {
-		return x.Item
+		return x.PageToken
 	}
 	return ""
 }
 
-func (x *UpdateOrderRequest) GetQuantity() int32 {
-	if x != nil {
-		return x.Quantity
-	}
-	return 0
-}
-
-type UpdateOrderResponse struct {
+type ListStockResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	Stocks        []*Stock               `protobuf:"bytes,1,rep,name=stocks,proto3" json:"stocks,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateOrderResponse) Reset() {
-	*x = UpdateOrderResponse{}
-	mi := &file_api_order_proto_msgTypes[6]
+func (x *ListStockResponse) Reset() {
+	*x = ListStockResponse{}
+	mi := &file_api_order_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateOrderResponse) String() string {
+func (x *ListStockResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateOrderResponse) ProtoMessage() {}
+func (*ListStockResponse) ProtoMessage() {}
 
-func (x *UpdateOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_order_proto_msgTypes[6]
+func (x *ListStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -358,40 +3294,48 @@ func (x *UpdateOrderResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateOrderResponse.ProtoReflect.Descriptor instead.
-func (*UpdateOrderResponse) Descriptor() ([]byte, []int) {
-	return file_api_order_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use ListStockResponse.ProtoReflect.Descriptor instead.
+func (*ListStockResponse) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{53}
 }
 
-func (x *UpdateOrderResponse) GetOrder() *Order {
+func (x *ListStockResponse) GetStocks() []*Stock {
 	if x != nil {
-		return x.Order
+		return x.Stocks
 	}
 	return nil
 }
 
-type DeleteOrderRequest struct {
+func (x *ListStockResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type RestockItemRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Item          string                 `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	Quantity      int64                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteOrderRequest) Reset() {
-	*x = DeleteOrderRequest{}
-	mi := &file_api_order_proto_msgTypes[7]
+func (x *RestockItemRequest) Reset() {
+	*x = RestockItemRequest{}
+	mi := &file_api_order_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteOrderRequest) String() string {
+func (x *RestockItemRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteOrderRequest) ProtoMessage() {}
+func (*RestockItemRequest) ProtoMessage() {}
 
-func (x *DeleteOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_order_proto_msgTypes[7]
+func (x *RestockItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -402,40 +3346,47 @@ func (x *DeleteOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteOrderRequest.ProtoReflect.Descriptor instead.
-func (*DeleteOrderRequest) Descriptor() ([]byte, []int) {
-	return file_api_order_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use RestockItemRequest.ProtoReflect.Descriptor instead.
+func (*RestockItemRequest) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *DeleteOrderRequest) GetId() string {
+func (x *RestockItemRequest) GetItem() string {
 	if x != nil {
-		return x.Id
+		return x.Item
 	}
 	return ""
 }
 
-type DeleteOrderResponse struct {
+func (x *RestockItemRequest) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type RestockItemResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Stock         *Stock                 `protobuf:"bytes,1,opt,name=stock,proto3" json:"stock,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteOrderResponse) Reset() {
-	*x = DeleteOrderResponse{}
-	mi := &file_api_order_proto_msgTypes[8]
+func (x *RestockItemResponse) Reset() {
+	*x = RestockItemResponse{}
+	mi := &file_api_order_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteOrderResponse) String() string {
+func (x *RestockItemResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteOrderResponse) ProtoMessage() {}
+func (*RestockItemResponse) ProtoMessage() {}
 
-func (x *DeleteOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_order_proto_msgTypes[8]
+func (x *RestockItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -446,39 +3397,41 @@ func (x *DeleteOrderResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteOrderResponse.ProtoReflect.Descriptor instead.
-func (*DeleteOrderResponse) Descriptor() ([]byte, []int) {
-	return file_api_order_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use RestockItemResponse.ProtoReflect.Descriptor instead.
+func (*RestockItemResponse) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{55}
 }
 
-func (x *DeleteOrderResponse) GetSuccess() bool {
+func (x *RestockItemResponse) GetStock() *Stock {
 	if x != nil {
-		return x.Success
+		return x.Stock
 	}
-	return false
+	return nil
 }
 
-type ListOrdersRequest struct {
+type AdjustStockRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Item          string                 `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	Delta         int64                  `protobuf:"varint,2,opt,name=delta,proto3" json:"delta,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListOrdersRequest) Reset() {
-	*x = ListOrdersRequest{}
-	mi := &file_api_order_proto_msgTypes[9]
+func (x *AdjustStockRequest) Reset() {
+	*x = AdjustStockRequest{}
+	mi := &file_api_order_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListOrdersRequest) String() string {
+func (x *AdjustStockRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListOrdersRequest) ProtoMessage() {}
+func (*AdjustStockRequest) ProtoMessage() {}
 
-func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_order_proto_msgTypes[9]
+func (x *AdjustStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -489,33 +3442,47 @@ func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListOrdersRequest.ProtoReflect.Descriptor instead.
-func (*ListOrdersRequest) Descriptor() ([]byte, []int) {
-	return file_api_order_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use AdjustStockRequest.ProtoReflect.Descriptor instead.
+func (*AdjustStockRequest) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{56}
 }
 
-type ListOrdersResponse struct {
+func (x *AdjustStockRequest) GetItem() string {
+	if x != nil {
+		return x.Item
+	}
+	return ""
+}
+
+func (x *AdjustStockRequest) GetDelta() int64 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+type AdjustStockResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	Stock         *Stock                 `protobuf:"bytes,1,opt,name=stock,proto3" json:"stock,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListOrdersResponse) Reset() {
-	*x = ListOrdersResponse{}
-	mi := &file_api_order_proto_msgTypes[10]
+func (x *AdjustStockResponse) Reset() {
+	*x = AdjustStockResponse{}
+	mi := &file_api_order_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListOrdersResponse) String() string {
+func (x *AdjustStockResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListOrdersResponse) ProtoMessage() {}
+func (*AdjustStockResponse) ProtoMessage() {}
 
-func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_order_proto_msgTypes[10]
+func (x *AdjustStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_order_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -526,14 +3493,14 @@ func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListOrdersResponse.ProtoReflect.Descriptor instead.
-func (*ListOrdersResponse) Descriptor() ([]byte, []int) {
-	return file_api_order_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use AdjustStockResponse.ProtoReflect.Descriptor instead.
+func (*AdjustStockResponse) Descriptor() ([]byte, []int) {
+	return file_api_order_proto_rawDescGZIP(), []int{57}
 }
 
-func (x *ListOrdersResponse) GetOrders() []*Order {
+func (x *AdjustStockResponse) GetStock() *Stock {
 	if x != nil {
-		return x.Orders
+		return x.Stock
 	}
 	return nil
 }
@@ -542,45 +3509,324 @@ var File_api_order_proto protoreflect.FileDescriptor
 
 const file_api_order_proto_rawDesc = "" +
 	"\n" +
-	"\x0fapi/order.proto\x12\x03api\x1a\x1cgoogle/api/annotations.proto\"G\n" +
-	"\x05Order\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04item\x18\x02 \x01(\tR\x04item\x12\x1a\n" +
-	"\bquantity\x18\x03 \x01(\x05R\bquantity\"D\n" +
-	"\x12CreateOrderRequest\x12\x12\n" +
-	"\x04item\x18\x01 \x01(\tR\x04item\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x05R\bquantity\"%\n" +
+	"\x0fapi/order.proto\x12\x03api\x1a\x1bbuf/validate/validate.proto\x1a\x1cgoogle/api/annotations.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x17google/type/money.proto\"\x82\x01\n" +
+	"\bLineItem\x12\x1e\n" +
+	"\x04item\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\x04item\x12#\n" +
+	"\bquantity\x18\x02 \x01(\x05B\a\xbaH\x04\x1a\x02 \x00R\bquantity\x121\n" +
+	"\n" +
+	"unit_price\x18\x03 \x01(\v2\x12.google.type.MoneyR\tunitPrice\"\x9e\x06\n" +
+	"\x05Order\x12\x19\n" +
+	"\x02id\x18\x01 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18$R\x02id\x12\x1c\n" +
+	"\x04item\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x18\xff\x01R\x04item\x12#\n" +
+	"\bquantity\x18\x03 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\bquantity\x12(\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x10.api.OrderStatusR\x06status\x127\n" +
+	"\n" +
+	"line_items\x18\x05 \x03(\v2\r.api.LineItemB\t\xbaH\x06\x92\x01\x03\x10\xe8\aR\tlineItems\x12\x18\n" +
+	"\aversion\x18\x06 \x01(\x03R\aversion\x12\x12\n" +
+	"\x04etag\x18\a \x01(\tR\x04etag\x12;\n" +
+	"\vdelete_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"deleteTime\x12;\n" +
+	"\vcreate_time\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x12;\n" +
+	"\vupdate_time\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"updateTime\x12(\n" +
+	"\vcustomer_id\x18\v \x01(\tB\a\xbaH\x04r\x02\x18@R\n" +
+	"customerId\x12(\n" +
+	"\x05total\x18\f \x01(\v2\x12.google.type.MoneyR\x05total\x12\xa2\x01\n" +
+	"\x06labels\x18\r \x03(\v2\x16.api.Order.LabelsEntryBr\xbaHo\x9a\x01l\x10@\"3r1\x10\x01\x18?2+^[A-Za-z0-9]([A-Za-z0-9._/-]*[A-Za-z0-9])?$*3r1\x18?2-^([A-Za-z0-9]([A-Za-z0-9._-]*[A-Za-z0-9])?)?$R\x06labels\x12;\n" +
+	"\vexpire_time\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"expireTime\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x86\x04\n" +
+	"\x12CreateOrderRequest\x12\x1c\n" +
+	"\x04item\x18\x01 \x01(\tB\b\xbaH\x05r\x03\x18\xff\x01R\x04item\x12#\n" +
+	"\bquantity\x18\x02 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\bquantity\x127\n" +
+	"\n" +
+	"line_items\x18\x03 \x03(\v2\r.api.LineItemB\t\xbaH\x06\x92\x01\x03\x10\xe8\aR\tlineItems\x12'\n" +
+	"\n" +
+	"request_id\x18\x04 \x01(\tB\b\xbaH\x05r\x03\x18\xff\x01R\trequestId\x12(\n" +
+	"\vcustomer_id\x18\x05 \x01(\tB\a\xbaH\x04r\x02\x18@R\n" +
+	"customerId\x124\n" +
+	"\border_id\x18\x06 \x01(\tB\x19\xbaH\x16r\x14\x18$2\x10^[A-Za-z0-9_-]*$R\aorderId\x12\xaf\x01\n" +
+	"\x06labels\x18\a \x03(\v2#.api.CreateOrderRequest.LabelsEntryBr\xbaHo\x9a\x01l\x10@\"3r1\x10\x01\x18?2+^[A-Za-z0-9]([A-Za-z0-9._/-]*[A-Za-z0-9])?$*3r1\x18?2-^([A-Za-z0-9]([A-Za-z0-9._-]*[A-Za-z0-9])?)?$R\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"%\n" +
 	"\x13CreateOrderResponse\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"!\n" +
-	"\x0fGetOrderRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"4\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\",\n" +
+	"\x0fGetOrderRequest\x12\x19\n" +
+	"\x02id\x18\x01 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18$R\x02id\"x\n" +
+	"\x16GetOrderHistoryRequest\x12\x19\n" +
+	"\x02id\x18\x01 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18$R\x02id\x12$\n" +
+	"\tpage_size\x18\x02 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"s\n" +
+	"\x17GetOrderHistoryResponse\x120\n" +
+	"\aentries\x18\x01 \x03(\v2\x16.api.OrderHistoryEntryR\aentries\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xe9\x01\n" +
+	"\x11OrderHistoryEntry\x12/\n" +
+	"\x06action\x18\x01 \x01(\x0e2\x17.api.OrderHistoryActionR\x06action\x12\x14\n" +
+	"\x05actor\x18\x02 \x01(\tR\x05actor\x12;\n" +
+	"\vchange_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"changeTime\x12'\n" +
+	"\told_order\x18\x04 \x01(\v2\n" +
+	".api.OrderR\boldOrder\x12'\n" +
+	"\tnew_order\x18\x05 \x01(\v2\n" +
+	".api.OrderR\bnewOrder\"4\n" +
 	"\x10GetOrderResponse\x12 \n" +
 	"\x05order\x18\x01 \x01(\v2\n" +
-	".api.OrderR\x05order\"T\n" +
-	"\x12UpdateOrderRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04item\x18\x02 \x01(\tR\x04item\x12\x1a\n" +
-	"\bquantity\x18\x03 \x01(\x05R\bquantity\"7\n" +
+	".api.OrderR\x05order\"\xa1\x01\n" +
+	"\x12UpdateOrderRequest\x12(\n" +
+	"\x05order\x18\x05 \x01(\v2\n" +
+	".api.OrderB\x06\xbaH\x03\xc8\x01\x01R\x05order\x12;\n" +
+	"\vupdate_mask\x18\x06 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMaskJ\x04\b\x01\x10\x05R\x02idR\x04itemR\bquantityR\n" +
+	"line_items\"7\n" +
 	"\x13UpdateOrderResponse\x12 \n" +
 	"\x05order\x18\x01 \x01(\v2\n" +
-	".api.OrderR\x05order\"$\n" +
-	"\x12DeleteOrderRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"/\n" +
+	".api.OrderR\x05order\"C\n" +
+	"\x12DeleteOrderRequest\x12\x19\n" +
+	"\x02id\x18\x01 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18$R\x02id\x12\x12\n" +
+	"\x04etag\x18\x02 \x01(\tR\x04etag\"/\n" +
 	"\x13DeleteOrderResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x13\n" +
-	"\x11ListOrdersRequest\"8\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"Z\n" +
+	"\x18BatchCreateOrdersRequest\x12>\n" +
+	"\brequests\x18\x01 \x03(\v2\x17.api.CreateOrderRequestB\t\xbaH\x06\x92\x01\x03\x10\xe8\aR\brequests\"?\n" +
+	"\x19BatchCreateOrdersResponse\x12\"\n" +
+	"\x06orders\x18\x01 \x03(\v2\n" +
+	".api.OrderR\x06orders\"<\n" +
+	"\x15BatchGetOrdersRequest\x12#\n" +
+	"\x03ids\x18\x01 \x03(\tB\x11\xbaH\x0e\x92\x01\v\x10\xe8\a\"\x06r\x04\x10\x01\x18$R\x03ids\"<\n" +
+	"\x16BatchGetOrdersResponse\x12\"\n" +
+	"\x06orders\x18\x01 \x03(\v2\n" +
+	".api.OrderR\x06orders\"\x93\x04\n" +
+	"\x13ImportOrdersRequest\x12\x1c\n" +
+	"\x04item\x18\x01 \x01(\tB\b\xbaH\x05r\x03\x18\xff\x01R\x04item\x12#\n" +
+	"\bquantity\x18\x02 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\bquantity\x127\n" +
+	"\n" +
+	"line_items\x18\x03 \x03(\v2\r.api.LineItemB\t\xbaH\x06\x92\x01\x03\x10\xe8\aR\tlineItems\x12(\n" +
+	"\vcustomer_id\x18\x04 \x01(\tB\a\xbaH\x04r\x02\x18@R\n" +
+	"customerId\x124\n" +
+	"\border_id\x18\x05 \x01(\tB\x19\xbaH\x16r\x14\x18$2\x10^[A-Za-z0-9_-]*$R\aorderId\x12\xb0\x01\n" +
+	"\x06labels\x18\x06 \x03(\v2$.api.ImportOrdersRequest.LabelsEntryBr\xbaHo\x9a\x01l\x10@\"3r1\x10\x01\x18?2+^[A-Za-z0-9]([A-Za-z0-9._/-]*[A-Za-z0-9])?$*3r1\x18?2-^([A-Za-z0-9]([A-Za-z0-9._-]*[A-Za-z0-9])?)?$R\x06labels\x122\n" +
+	"\x06status\x18\a \x01(\x0e2\x10.api.OrderStatusB\b\xbaH\x05\x82\x01\x02\x10\x01R\x06status\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"]\n" +
+	"\rImportFailure\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x03R\x05index\x12\x14\n" +
+	"\x05field\x18\x02 \x01(\tR\x05field\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\"\x96\x01\n" +
+	"\x14ImportOrdersResponse\x12\x1a\n" +
+	"\breceived\x18\x01 \x01(\x03R\breceived\x12\x1a\n" +
+	"\bimported\x18\x02 \x01(\x03R\bimported\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x03R\x06failed\x12.\n" +
+	"\bfailures\x18\x04 \x03(\v2\x12.api.ImportFailureR\bfailures\"?\n" +
+	"\x18BatchDeleteOrdersRequest\x12#\n" +
+	"\x03ids\x18\x01 \x03(\tB\x11\xbaH\x0e\x92\x01\v\x10\xe8\a\"\x06r\x04\x10\x01\x18$R\x03ids\"5\n" +
+	"\x19BatchDeleteOrdersResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"1\n" +
+	"\x14UndeleteOrderRequest\x12\x19\n" +
+	"\x02id\x18\x01 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18$R\x02id\"9\n" +
+	"\x15UndeleteOrderResponse\x12 \n" +
+	"\x05order\x18\x01 \x01(\v2\n" +
+	".api.OrderR\x05order\"\xf3\x01\n" +
+	"\x11ListOrdersRequest\x12$\n" +
+	"\tpage_size\x18\x01 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12 \n" +
+	"\x06filter\x18\x03 \x01(\tB\b\xbaH\x05r\x03\x18\x80\x10R\x06filter\x12#\n" +
+	"\border_by\x18\x04 \x01(\tB\b\xbaH\x05r\x03\x18\x80\x02R\aorderBy\x12!\n" +
+	"\fshow_deleted\x18\x05 \x01(\bR\vshowDeleted\x12/\n" +
+	"\x0elabel_selector\x18\x06 \x01(\tB\b\xbaH\x05r\x03\x18\x80\bR\rlabelSelector\"`\n" +
 	"\x12ListOrdersResponse\x12\"\n" +
 	"\x06orders\x18\x01 \x03(\v2\n" +
-	".api.OrderR\x06orders2\xc5\x03\n" +
+	".api.OrderR\x06orders\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xa7\x02\n" +
+	"\x19ListCustomerOrdersRequest\x12*\n" +
+	"\vcustomer_id\x18\x01 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18@R\n" +
+	"customerId\x12$\n" +
+	"\tpage_size\x18\x02 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12 \n" +
+	"\x06filter\x18\x04 \x01(\tB\b\xbaH\x05r\x03\x18\x80\x10R\x06filter\x12#\n" +
+	"\border_by\x18\x05 \x01(\tB\b\xbaH\x05r\x03\x18\x80\x02R\aorderBy\x12!\n" +
+	"\fshow_deleted\x18\x06 \x01(\bR\vshowDeleted\x12/\n" +
+	"\x0elabel_selector\x18\a \x01(\tB\b\xbaH\x05r\x03\x18\x80\bR\rlabelSelector\"h\n" +
+	"\x1aListCustomerOrdersResponse\x12\"\n" +
+	"\x06orders\x18\x01 \x03(\v2\n" +
+	".api.OrderR\x06orders\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"0\n" +
+	"\x13ConfirmOrderRequest\x12\x19\n" +
+	"\x02id\x18\x01 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18$R\x02id\"8\n" +
+	"\x14ConfirmOrderResponse\x12 \n" +
+	"\x05order\x18\x01 \x01(\v2\n" +
+	".api.OrderR\x05order\",\n" +
+	"\x0fPayOrderRequest\x12\x19\n" +
+	"\x02id\x18\x01 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18$R\x02id\"4\n" +
+	"\x10PayOrderResponse\x12 \n" +
+	"\x05order\x18\x01 \x01(\v2\n" +
+	".api.OrderR\x05order\"-\n" +
+	"\x10ShipOrderRequest\x12\x19\n" +
+	"\x02id\x18\x01 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18$R\x02id\"5\n" +
+	"\x11ShipOrderResponse\x12 \n" +
+	"\x05order\x18\x01 \x01(\v2\n" +
+	".api.OrderR\x05order\"0\n" +
+	"\x13DeliverOrderRequest\x12\x19\n" +
+	"\x02id\x18\x01 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18$R\x02id\"8\n" +
+	"\x14DeliverOrderResponse\x12 \n" +
+	"\x05order\x18\x01 \x01(\v2\n" +
+	".api.OrderR\x05order\"/\n" +
+	"\x12CancelOrderRequest\x12\x19\n" +
+	"\x02id\x18\x01 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18$R\x02id\"7\n" +
+	"\x13CancelOrderResponse\x12 \n" +
+	"\x05order\x18\x01 \x01(\v2\n" +
+	".api.OrderR\x05order\"\xb0\x01\n" +
+	"\x13StreamOrdersRequest\x12 \n" +
+	"\x06filter\x18\x01 \x01(\tB\b\xbaH\x05r\x03\x18\x80\x10R\x06filter\x12#\n" +
+	"\border_by\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x18\x80\x02R\aorderBy\x12!\n" +
+	"\fshow_deleted\x18\x03 \x01(\bR\vshowDeleted\x12/\n" +
+	"\x0elabel_selector\x18\x04 \x01(\tB\b\xbaH\x05r\x03\x18\x80\bR\rlabelSelector\"8\n" +
+	"\x14StreamOrdersResponse\x12 \n" +
+	"\x05order\x18\x01 \x01(\v2\n" +
+	".api.OrderR\x05order\"\x97\x01\n" +
+	"\x13SearchOrdersRequest\x12\x18\n" +
+	"\x01q\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x02R\x01q\x12$\n" +
+	"\tpage_size\x18\x02 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12!\n" +
+	"\fshow_deleted\x18\x04 \x01(\bR\vshowDeleted\"b\n" +
+	"\x14SearchOrdersResponse\x12\"\n" +
+	"\x06orders\x18\x01 \x03(\v2\n" +
+	".api.OrderR\x06orders\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xc3\x01\n" +
+	"\x14GetOrderStatsRequest\x12A\n" +
+	"\n" +
+	"start_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampB\x06\xbaH\x03\xc8\x01\x01R\tstartTime\x12=\n" +
+	"\bend_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampB\x06\xbaH\x03\xc8\x01\x01R\aendTime\x12)\n" +
+	"\n" +
+	"item_limit\x18\x03 \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\xe8\a(\x00R\titemLimit\">\n" +
+	"\x15GetOrderStatsResponse\x12%\n" +
+	"\x05stats\x18\x01 \x01(\v2\x0f.api.OrderStatsR\x05stats\"\x91\x02\n" +
+	"\n" +
+	"OrderStats\x129\n" +
+	"\n" +
+	"start_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12!\n" +
+	"\ftotal_orders\x18\x03 \x01(\x03R\vtotalOrders\x12%\n" +
+	"\x0etotal_quantity\x18\x04 \x01(\x03R\rtotalQuantity\x12$\n" +
+	"\x05items\x18\x05 \x03(\v2\x0e.api.ItemStatsR\x05items\x12!\n" +
+	"\x04days\x18\x06 \x03(\v2\r.api.DayStatsR\x04days\"S\n" +
+	"\tItemStats\x12\x12\n" +
+	"\x04item\x18\x01 \x01(\tR\x04item\x12\x16\n" +
+	"\x06orders\x18\x02 \x01(\x03R\x06orders\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x03R\bquantity\"y\n" +
+	"\bDayStats\x129\n" +
+	"\n" +
+	"start_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x12\x16\n" +
+	"\x06orders\x18\x02 \x01(\x03R\x06orders\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x03R\bquantity\"S\n" +
+	"\x12WatchOrdersRequest\x12=\n" +
+	"\fresume_token\x18\x01 \x01(\tB\x1a\xbaH\x17r\x152\x13^([0-9]+\\.[0-9]+)?$R\vresumeToken\"\xd9\x01\n" +
+	"\x13WatchOrdersResponse\x12'\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x13.api.OrderEventTypeR\x04type\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12 \n" +
+	"\x05order\x18\x03 \x01(\v2\n" +
+	".api.OrderR\x05order\x129\n" +
+	"\n" +
+	"event_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\teventTime\x12!\n" +
+	"\fresume_token\x18\x05 \x01(\tR\vresumeToken\"\x92\x01\n" +
+	"\x05Stock\x12\x12\n" +
+	"\x04item\x18\x01 \x01(\tR\x04item\x12\x1c\n" +
+	"\tavailable\x18\x02 \x01(\x03R\tavailable\x12\x1a\n" +
+	"\breserved\x18\x03 \x01(\x03R\breserved\x12;\n" +
+	"\vupdate_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"updateTime\"1\n" +
+	"\x0fGetStockRequest\x12\x1e\n" +
+	"\x04item\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\x04item\"4\n" +
+	"\x10GetStockResponse\x12 \n" +
+	"\x05stock\x18\x01 \x01(\v2\n" +
+	".api.StockR\x05stock\"W\n" +
+	"\x10ListStockRequest\x12$\n" +
+	"\tpage_size\x18\x01 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"_\n" +
+	"\x11ListStockResponse\x12\"\n" +
+	"\x06stocks\x18\x01 \x03(\v2\n" +
+	".api.StockR\x06stocks\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"_\n" +
+	"\x12RestockItemRequest\x12\x1e\n" +
+	"\x04item\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\x04item\x12)\n" +
+	"\bquantity\x18\x02 \x01(\x03B\r\xbaH\n" +
+	"\"\b\x18\x80\x94\xeb\xdc\x03 \x00R\bquantity\"7\n" +
+	"\x13RestockItemResponse\x12 \n" +
+	"\x05stock\x18\x01 \x01(\v2\n" +
+	".api.StockR\x05stock\"d\n" +
+	"\x12AdjustStockRequest\x12\x1e\n" +
+	"\x04item\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\x04item\x12.\n" +
+	"\x05delta\x18\x02 \x01(\x03B\x18\xbaH\x15\"\x138\x00\x18\x80\x94\xeb\xdc\x03(\x80씣\xfc\xff\xff\xff\xff\x01R\x05delta\"7\n" +
+	"\x13AdjustStockResponse\x12 \n" +
+	"\x05stock\x18\x01 \x01(\v2\n" +
+	".api.StockR\x05stock*\xca\x01\n" +
+	"\vOrderStatus\x12\x1c\n" +
+	"\x18ORDER_STATUS_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14ORDER_STATUS_PENDING\x10\x01\x12\x1a\n" +
+	"\x16ORDER_STATUS_CONFIRMED\x10\x02\x12\x15\n" +
+	"\x11ORDER_STATUS_PAID\x10\x03\x12\x18\n" +
+	"\x14ORDER_STATUS_SHIPPED\x10\x04\x12\x1a\n" +
+	"\x16ORDER_STATUS_DELIVERED\x10\x05\x12\x1a\n" +
+	"\x16ORDER_STATUS_CANCELLED\x10\x06*\x8e\x02\n" +
+	"\x12OrderHistoryAction\x12$\n" +
+	" ORDER_HISTORY_ACTION_UNSPECIFIED\x10\x00\x12 \n" +
+	"\x1cORDER_HISTORY_ACTION_CREATED\x10\x01\x12 \n" +
+	"\x1cORDER_HISTORY_ACTION_UPDATED\x10\x02\x12 \n" +
+	"\x1cORDER_HISTORY_ACTION_DELETED\x10\x03\x12\"\n" +
+	"\x1eORDER_HISTORY_ACTION_UNDELETED\x10\x04\x12'\n" +
+	"#ORDER_HISTORY_ACTION_STATUS_CHANGED\x10\x05\x12\x1f\n" +
+	"\x1bORDER_HISTORY_ACTION_PURGED\x10\x06*\x8c\x01\n" +
+	"\x0eOrderEventType\x12 \n" +
+	"\x1cORDER_EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18ORDER_EVENT_TYPE_CREATED\x10\x01\x12\x1c\n" +
+	"\x18ORDER_EVENT_TYPE_UPDATED\x10\x02\x12\x1c\n" +
+	"\x18ORDER_EVENT_TYPE_DELETED\x10\x032\xfa\x10\n" +
 	"\fOrderService\x12W\n" +
 	"\vCreateOrder\x12\x17.api.CreateOrderRequest\x1a\x18.api.CreateOrderResponse\"\x15\x82\xd3\xe4\x93\x02\x0f:\x01*\"\n" +
 	"/v1/orders\x12P\n" +
-	"\bGetOrder\x12\x14.api.GetOrderRequest\x1a\x15.api.GetOrderResponse\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/v1/orders/{id}\x12\\\n" +
-	"\vUpdateOrder\x12\x17.api.UpdateOrderRequest\x1a\x18.api.UpdateOrderResponse\"\x1a\x82\xd3\xe4\x93\x02\x14:\x01*\x1a\x0f/v1/orders/{id}\x12Y\n" +
-	"\vDeleteOrder\x12\x17.api.DeleteOrderRequest\x1a\x18.api.DeleteOrderResponse\"\x17\x82\xd3\xe4\x93\x02\x11*\x0f/v1/orders/{id}\x12Q\n" +
+	"\bGetOrder\x12\x14.api.GetOrderRequest\x1a\x15.api.GetOrderResponse\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/v1/orders/{id}\x12m\n" +
+	"\x0fGetOrderHistory\x12\x1b.api.GetOrderHistoryRequest\x1a\x1c.api.GetOrderHistoryResponse\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/v1/orders/{id}/history\x12\x86\x01\n" +
+	"\vUpdateOrder\x12\x17.api.UpdateOrderRequest\x1a\x18.api.UpdateOrderResponse\"D\x82\xd3\xe4\x93\x02>:\x05orderZ\x1e:\x05order\x1a\x15/v1/orders/{order.id}2\x15/v1/orders/{order.id}\x12Y\n" +
+	"\vDeleteOrder\x12\x17.api.DeleteOrderRequest\x1a\x18.api.DeleteOrderResponse\"\x17\x82\xd3\xe4\x93\x02\x11*\x0f/v1/orders/{id}\x12u\n" +
+	"\x11BatchCreateOrders\x12\x1d.api.BatchCreateOrdersRequest\x1a\x1e.api.BatchCreateOrdersResponse\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/v1/orders:batchCreate\x12f\n" +
+	"\x0eBatchGetOrders\x12\x1a.api.BatchGetOrdersRequest\x1a\x1b.api.BatchGetOrdersResponse\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/orders:batchGet\x12u\n" +
+	"\x11BatchDeleteOrders\x12\x1d.api.BatchDeleteOrdersRequest\x1a\x1e.api.BatchDeleteOrdersResponse\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/v1/orders:batchDelete\x12c\n" +
+	"\fImportOrders\x12\x18.api.ImportOrdersRequest\x1a\x19.api.ImportOrdersResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/v1/orders:import(\x01\x12k\n" +
+	"\rUndeleteOrder\x12\x19.api.UndeleteOrderRequest\x1a\x1a.api.UndeleteOrderResponse\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/v1/orders/{id}:undelete\x12Q\n" +
 	"\n" +
 	"ListOrders\x12\x16.api.ListOrdersRequest\x1a\x17.api.ListOrdersResponse\"\x12\x82\xd3\xe4\x93\x02\f\x12\n" +
-	"/v1/ordersB\x0eZ\fpkg/api/testb\x06proto3"
+	"/v1/orders\x12\x81\x01\n" +
+	"\x12ListCustomerOrders\x12\x1e.api.ListCustomerOrdersRequest\x1a\x1f.api.ListCustomerOrdersResponse\"*\x82\xd3\xe4\x93\x02$\x12\"/v1/customers/{customer_id}/orders\x12`\n" +
+	"\fStreamOrders\x12\x18.api.StreamOrdersRequest\x1a\x19.api.StreamOrdersResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/orders:stream0\x01\x12^\n" +
+	"\fSearchOrders\x12\x18.api.SearchOrdersRequest\x1a\x19.api.SearchOrdersResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/orders:search\x12`\n" +
+	"\rGetOrderStats\x12\x19.api.GetOrderStatsRequest\x1a\x1a.api.GetOrderStatsResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/orders:stats\x12\\\n" +
+	"\vWatchOrders\x12\x17.api.WatchOrdersRequest\x1a\x18.api.WatchOrdersResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/orders:watch0\x01\x12g\n" +
+	"\fConfirmOrder\x12\x18.api.ConfirmOrderRequest\x1a\x19.api.ConfirmOrderResponse\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/v1/orders/{id}:confirm\x12W\n" +
+	"\bPayOrder\x12\x14.api.PayOrderRequest\x1a\x15.api.PayOrderResponse\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/v1/orders/{id}:pay\x12[\n" +
+	"\tShipOrder\x12\x15.api.ShipOrderRequest\x1a\x16.api.ShipOrderResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/v1/orders/{id}:ship\x12g\n" +
+	"\fDeliverOrder\x12\x18.api.DeliverOrderRequest\x1a\x19.api.DeliverOrderResponse\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/v1/orders/{id}:deliver\x12c\n" +
+	"\vCancelOrder\x12\x17.api.CancelOrderRequest\x1a\x18.api.CancelOrderResponse\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/v1/orders/{id}:cancel2\x91\x03\n" +
+	"\x10InventoryService\x12U\n" +
+	"\bGetStock\x12\x14.api.GetStockRequest\x1a\x15.api.GetStockResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/v1/inventory/{item}\x12Q\n" +
+	"\tListStock\x12\x15.api.ListStockRequest\x1a\x16.api.ListStockResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/v1/inventory\x12i\n" +
+	"\vRestockItem\x12\x17.api.RestockItemRequest\x1a\x18.api.RestockItemResponse\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/v1/inventory/{item}:restock\x12h\n" +
+	"\vAdjustStock\x12\x17.api.AdjustStockRequest\x1a\x18.api.AdjustStockResponse\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/v1/inventory/{item}:adjustB\x0eZ\fpkg/api/testb\x06proto3"
 
 var (
 	file_api_order_proto_rawDescOnce sync.Once
@@ -594,39 +3840,186 @@ func file_api_order_proto_rawDescGZIP() []byte {
 	return file_api_order_proto_rawDescData
 }
 
-var file_api_order_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_api_order_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_api_order_proto_msgTypes = make([]protoimpl.MessageInfo, 61)
 var file_api_order_proto_goTypes = []any{
-	(*Order)(nil),               // 0: api.Order
-	(*CreateOrderRequest)(nil),  // 1: api.CreateOrderRequest
-	(*CreateOrderResponse)(nil), // 2: api.CreateOrderResponse
-	(*GetOrderRequest)(nil),     // 3: api.GetOrderRequest
-	(*GetOrderResponse)(nil),    // 4: api.GetOrderResponse
-	(*UpdateOrderRequest)(nil),  // 5: api.UpdateOrderRequest
-	(*UpdateOrderResponse)(nil), // 6: api.UpdateOrderResponse
-	(*DeleteOrderRequest)(nil),  // 7: api.DeleteOrderRequest
-	(*DeleteOrderResponse)(nil), // 8: api.DeleteOrderResponse
-	(*ListOrdersRequest)(nil),   // 9: api.ListOrdersRequest
-	(*ListOrdersResponse)(nil),  // 10: api.ListOrdersResponse
+	(OrderStatus)(0),                   // 0: api.OrderStatus
+	(OrderHistoryAction)(0),            // 1: api.OrderHistoryAction
+	(OrderEventType)(0),                // 2: api.OrderEventType
+	(*LineItem)(nil),                   // 3: api.LineItem
+	(*Order)(nil),                      // 4: api.Order
+	(*CreateOrderRequest)(nil),         // 5: api.CreateOrderRequest
+	(*CreateOrderResponse)(nil),        // 6: api.CreateOrderResponse
+	(*GetOrderRequest)(nil),            // 7: api.GetOrderRequest
+	(*GetOrderHistoryRequest)(nil),     // 8: api.GetOrderHistoryRequest
+	(*GetOrderHistoryResponse)(nil),    // 9: api.GetOrderHistoryResponse
+	(*OrderHistoryEntry)(nil),          // 10: api.OrderHistoryEntry
+	(*GetOrderResponse)(nil),           // 11: api.GetOrderResponse
+	(*UpdateOrderRequest)(nil),         // 12: api.UpdateOrderRequest
+	(*UpdateOrderResponse)(nil),        // 13: api.UpdateOrderResponse
+	(*DeleteOrderRequest)(nil),         // 14: api.DeleteOrderRequest
+	(*DeleteOrderResponse)(nil),        // 15: api.DeleteOrderResponse
+	(*BatchCreateOrdersRequest)(nil),   // 16: api.BatchCreateOrdersRequest
+	(*BatchCreateOrdersResponse)(nil),  // 17: api.BatchCreateOrdersResponse
+	(*BatchGetOrdersRequest)(nil),      // 18: api.BatchGetOrdersRequest
+	(*BatchGetOrdersResponse)(nil),     // 19: api.BatchGetOrdersResponse
+	(*ImportOrdersRequest)(nil),        // 20: api.ImportOrdersRequest
+	(*ImportFailure)(nil),              // 21: api.ImportFailure
+	(*ImportOrdersResponse)(nil),       // 22: api.ImportOrdersResponse
+	(*BatchDeleteOrdersRequest)(nil),   // 23: api.BatchDeleteOrdersRequest
+	(*BatchDeleteOrdersResponse)(nil),  // 24: api.BatchDeleteOrdersResponse
+	(*UndeleteOrderRequest)(nil),       // 25: api.UndeleteOrderRequest
+	(*UndeleteOrderResponse)(nil),      // 26: api.UndeleteOrderResponse
+	(*ListOrdersRequest)(nil),          // 27: api.ListOrdersRequest
+	(*ListOrdersResponse)(nil),         // 28: api.ListOrdersResponse
+	(*ListCustomerOrdersRequest)(nil),  // 29: api.ListCustomerOrdersRequest
+	(*ListCustomerOrdersResponse)(nil), // 30: api.ListCustomerOrdersResponse
+	(*ConfirmOrderRequest)(nil),        // 31: api.ConfirmOrderRequest
+	(*ConfirmOrderResponse)(nil),       // 32: api.ConfirmOrderResponse
+	(*PayOrderRequest)(nil),            // 33: api.PayOrderRequest
+	(*PayOrderResponse)(nil),           // 34: api.PayOrderResponse
+	(*ShipOrderRequest)(nil),           // 35: api.ShipOrderRequest
+	(*ShipOrderResponse)(nil),          // 36: api.ShipOrderResponse
+	(*DeliverOrderRequest)(nil),        // 37: api.DeliverOrderRequest
+	(*DeliverOrderResponse)(nil),       // 38: api.DeliverOrderResponse
+	(*CancelOrderRequest)(nil),         // 39: api.CancelOrderRequest
+	(*CancelOrderResponse)(nil),        // 40: api.CancelOrderResponse
+	(*StreamOrdersRequest)(nil),        // 41: api.StreamOrdersRequest
+	(*StreamOrdersResponse)(nil),       // 42: api.StreamOrdersResponse
+	(*SearchOrdersRequest)(nil),        // 43: api.SearchOrdersRequest
+	(*SearchOrdersResponse)(nil),       // 44: api.SearchOrdersResponse
+	(*GetOrderStatsRequest)(nil),       // 45: api.GetOrderStatsRequest
+	(*GetOrderStatsResponse)(nil),      // 46: api.GetOrderStatsResponse
+	(*OrderStats)(nil),                 // 47: api.OrderStats
+	(*ItemStats)(nil),                  // 48: api.ItemStats
+	(*DayStats)(nil),                   // 49: api.DayStats
+	(*WatchOrdersRequest)(nil),         // 50: api.WatchOrdersRequest
+	(*WatchOrdersResponse)(nil),        // 51: api.WatchOrdersResponse
+	(*Stock)(nil),                      // 52: api.Stock
+	(*GetStockRequest)(nil),            // 53: api.GetStockRequest
+	(*GetStockResponse)(nil),           // 54: api.GetStockResponse
+	(*ListStockRequest)(nil),           // 55: api.ListStockRequest
+	(*ListStockResponse)(nil),          // 56: api.ListStockResponse
+	(*RestockItemRequest)(nil),         // 57: api.RestockItemRequest
+	(*RestockItemResponse)(nil),        // 58: api.RestockItemResponse
+	(*AdjustStockRequest)(nil),         // 59: api.AdjustStockRequest
+	(*AdjustStockResponse)(nil),        // 60: api.AdjustStockResponse
+	nil,                                // 61: api.Order.LabelsEntry
+	nil,                                // 62: api.CreateOrderRequest.LabelsEntry
+	nil,                                // 63: api.ImportOrdersRequest.LabelsEntry
+	(*money.Money)(nil),                // 64: google.type.Money
+	(*timestamppb.Timestamp)(nil),      // 65: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),      // 66: google.protobuf.FieldMask
 }
 var file_api_order_proto_depIdxs = []int32{
-	0,  // 0: api.GetOrderResponse.order:type_name -> api.Order
-	0,  // 1: api.UpdateOrderResponse.order:type_name -> api.Order
-	0,  // 2: api.ListOrdersResponse.orders:type_name -> api.Order
-	1,  // 3: api.OrderService.CreateOrder:input_type -> api.CreateOrderRequest
-	3,  // 4: api.OrderService.GetOrder:input_type -> api.GetOrderRequest
-	5,  // 5: api.OrderService.UpdateOrder:input_type -> api.UpdateOrderRequest
-	7,  // 6: api.OrderService.DeleteOrder:input_type -> api.DeleteOrderRequest
-	9,  // 7: api.OrderService.ListOrders:input_type -> api.ListOrdersRequest
-	2,  // 8: api.OrderService.CreateOrder:output_type -> api.CreateOrderResponse
-	4,  // 9: api.OrderService.GetOrder:output_type -> api.GetOrderResponse
-	6,  // 10: api.OrderService.UpdateOrder:output_type -> api.UpdateOrderResponse
-	8,  // 11: api.OrderService.DeleteOrder:output_type -> api.DeleteOrderResponse
-	10, // 12: api.OrderService.ListOrders:output_type -> api.ListOrdersResponse
-	8,  // [8:13] is the sub-list for method output_type
-	3,  // [3:8] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+	64, // 0: api.LineItem.unit_price:type_name -> google.type.Money
+	0,  // 1: api.Order.status:type_name -> api.OrderStatus
+	3,  // 2: api.Order.line_items:type_name -> api.LineItem
+	65, // 3: api.Order.delete_time:type_name -> google.protobuf.Timestamp
+	65, // 4: api.Order.create_time:type_name -> google.protobuf.Timestamp
+	65, // 5: api.Order.update_time:type_name -> google.protobuf.Timestamp
+	64, // 6: api.Order.total:type_name -> google.type.Money
+	61, // 7: api.Order.labels:type_name -> api.Order.LabelsEntry
+	65, // 8: api.Order.expire_time:type_name -> google.protobuf.Timestamp
+	3,  // 9: api.CreateOrderRequest.line_items:type_name -> api.LineItem
+	62, // 10: api.CreateOrderRequest.labels:type_name -> api.CreateOrderRequest.LabelsEntry
+	10, // 11: api.GetOrderHistoryResponse.entries:type_name -> api.OrderHistoryEntry
+	1,  // 12: api.OrderHistoryEntry.action:type_name -> api.OrderHistoryAction
+	65, // 13: api.OrderHistoryEntry.change_time:type_name -> google.protobuf.Timestamp
+	4,  // 14: api.OrderHistoryEntry.old_order:type_name -> api.Order
+	4,  // 15: api.OrderHistoryEntry.new_order:type_name -> api.Order
+	4,  // 16: api.GetOrderResponse.order:type_name -> api.Order
+	4,  // 17: api.UpdateOrderRequest.order:type_name -> api.Order
+	66, // 18: api.UpdateOrderRequest.update_mask:type_name -> google.protobuf.FieldMask
+	4,  // 19: api.UpdateOrderResponse.order:type_name -> api.Order
+	5,  // 20: api.BatchCreateOrdersRequest.requests:type_name -> api.CreateOrderRequest
+	4,  // 21: api.BatchCreateOrdersResponse.orders:type_name -> api.Order
+	4,  // 22: api.BatchGetOrdersResponse.orders:type_name -> api.Order
+	3,  // 23: api.ImportOrdersRequest.line_items:type_name -> api.LineItem
+	63, // 24: api.ImportOrdersRequest.labels:type_name -> api.ImportOrdersRequest.LabelsEntry
+	0,  // 25: api.ImportOrdersRequest.status:type_name -> api.OrderStatus
+	21, // 26: api.ImportOrdersResponse.failures:type_name -> api.ImportFailure
+	4,  // 27: api.UndeleteOrderResponse.order:type_name -> api.Order
+	4,  // 28: api.ListOrdersResponse.orders:type_name -> api.Order
+	4,  // 29: api.ListCustomerOrdersResponse.orders:type_name -> api.Order
+	4,  // 30: api.ConfirmOrderResponse.order:type_name -> api.Order
+	4,  // 31: api.PayOrderResponse.order:type_name -> api.Order
+	4,  // 32: api.ShipOrderResponse.order:type_name -> api.Order
+	4,  // 33: api.DeliverOrderResponse.order:type_name -> api.Order
+	4,  // 34: api.CancelOrderResponse.order:type_name -> api.Order
+	4,  // 35: api.StreamOrdersResponse.order:type_name -> api.Order
+	4,  // 36: api.SearchOrdersResponse.orders:type_name -> api.Order
+	65, // 37: api.GetOrderStatsRequest.start_time:type_name -> google.protobuf.Timestamp
+	65, // 38: api.GetOrderStatsRequest.end_time:type_name -> google.protobuf.Timestamp
+	47, // 39: api.GetOrderStatsResponse.stats:type_name -> api.OrderStats
+	65, // 40: api.OrderStats.start_time:type_name -> google.protobuf.Timestamp
+	65, // 41: api.OrderStats.end_time:type_name -> google.protobuf.Timestamp
+	48, // 42: api.OrderStats.items:type_name -> api.ItemStats
+	49, // 43: api.OrderStats.days:type_name -> api.DayStats
+	65, // 44: api.DayStats.start_time:type_name -> google.protobuf.Timestamp
+	2,  // 45: api.WatchOrdersResponse.type:type_name -> api.OrderEventType
+	4,  // 46: api.WatchOrdersResponse.order:type_name -> api.Order
+	65, // 47: api.WatchOrdersResponse.event_time:type_name -> google.protobuf.Timestamp
+	65, // 48: api.Stock.update_time:type_name -> google.protobuf.Timestamp
+	52, // 49: api.GetStockResponse.stock:type_name -> api.Stock
+	52, // 50: api.ListStockResponse.stocks:type_name -> api.Stock
+	52, // 51: api.RestockItemResponse.stock:type_name -> api.Stock
+	52, // 52: api.AdjustStockResponse.stock:type_name -> api.Stock
+	5,  // 53: api.OrderService.CreateOrder:input_type -> api.CreateOrderRequest
+	7,  // 54: api.OrderService.GetOrder:input_type -> api.GetOrderRequest
+	8,  // 55: api.OrderService.GetOrderHistory:input_type -> api.GetOrderHistoryRequest
+	12, // 56: api.OrderService.UpdateOrder:input_type -> api.UpdateOrderRequest
+	14, // 57: api.OrderService.DeleteOrder:input_type -> api.DeleteOrderRequest
+	16, // 58: api.OrderService.BatchCreateOrders:input_type -> api.BatchCreateOrdersRequest
+	18, // 59: api.OrderService.BatchGetOrders:input_type -> api.BatchGetOrdersRequest
+	23, // 60: api.OrderService.BatchDeleteOrders:input_type -> api.BatchDeleteOrdersRequest
+	20, // 61: api.OrderService.ImportOrders:input_type -> api.ImportOrdersRequest
+	25, // 62: api.OrderService.UndeleteOrder:input_type -> api.UndeleteOrderRequest
+	27, // 63: api.OrderService.ListOrders:input_type -> api.ListOrdersRequest
+	29, // 64: api.OrderService.ListCustomerOrders:input_type -> api.ListCustomerOrdersRequest
+	41, // 65: api.OrderService.StreamOrders:input_type -> api.StreamOrdersRequest
+	43, // 66: api.OrderService.SearchOrders:input_type -> api.SearchOrdersRequest
+	45, // 67: api.OrderService.GetOrderStats:input_type -> api.GetOrderStatsRequest
+	50, // 68: api.OrderService.WatchOrders:input_type -> api.WatchOrdersRequest
+	31, // 69: api.OrderService.ConfirmOrder:input_type -> api.ConfirmOrderRequest
+	33, // 70: api.OrderService.PayOrder:input_type -> api.PayOrderRequest
+	35, // 71: api.OrderService.ShipOrder:input_type -> api.ShipOrderRequest
+	37, // 72: api.OrderService.DeliverOrder:input_type -> api.DeliverOrderRequest
+	39, // 73: api.OrderService.CancelOrder:input_type -> api.CancelOrderRequest
+	53, // 74: api.InventoryService.GetStock:input_type -> api.GetStockRequest
+	55, // 75: api.InventoryService.ListStock:input_type -> api.ListStockRequest
+	57, // 76: api.InventoryService.RestockItem:input_type -> api.RestockItemRequest
+	59, // 77: api.InventoryService.AdjustStock:input_type -> api.AdjustStockRequest
+	6,  // 78: api.OrderService.CreateOrder:output_type -> api.CreateOrderResponse
+	11, // 79: api.OrderService.GetOrder:output_type -> api.GetOrderResponse
+	9,  // 80: api.OrderService.GetOrderHistory:output_type -> api.GetOrderHistoryResponse
+	13, // 81: api.OrderService.UpdateOrder:output_type -> api.UpdateOrderResponse
+	15, // 82: api.OrderService.DeleteOrder:output_type -> api.DeleteOrderResponse
+	17, // 83: api.OrderService.BatchCreateOrders:output_type -> api.BatchCreateOrdersResponse
+	19, // 84: api.OrderService.BatchGetOrders:output_type -> api.BatchGetOrdersResponse
+	24, // 85: api.OrderService.BatchDeleteOrders:output_type -> api.BatchDeleteOrdersResponse
+	22, // 86: api.OrderService.ImportOrders:output_type -> api.ImportOrdersResponse
+	26, // 87: api.OrderService.UndeleteOrder:output_type -> api.UndeleteOrderResponse
+	28, // 88: api.OrderService.ListOrders:output_type -> api.ListOrdersResponse
+	30, // 89: api.OrderService.ListCustomerOrders:output_type -> api.ListCustomerOrdersResponse
+	42, // 90: api.OrderService.StreamOrders:output_type -> api.StreamOrdersResponse
+	44, // 91: api.OrderService.SearchOrders:output_type -> api.SearchOrdersResponse
+	46, // 92: api.OrderService.GetOrderStats:output_type -> api.GetOrderStatsResponse
+	51, // 93: api.OrderService.WatchOrders:output_type -> api.WatchOrdersResponse
+	32, // 94: api.OrderService.ConfirmOrder:output_type -> api.ConfirmOrderResponse
+	34, // 95: api.OrderService.PayOrder:output_type -> api.PayOrderResponse
+	36, // 96: api.OrderService.ShipOrder:output_type -> api.ShipOrderResponse
+	38, // 97: api.OrderService.DeliverOrder:output_type -> api.DeliverOrderResponse
+	40, // 98: api.OrderService.CancelOrder:output_type -> api.CancelOrderResponse
+	54, // 99: api.InventoryService.GetStock:output_type -> api.GetStockResponse
+	56, // 100: api.InventoryService.ListStock:output_type -> api.ListStockResponse
+	58, // 101: api.InventoryService.RestockItem:output_type -> api.RestockItemResponse
+	60, // 102: api.InventoryService.AdjustStock:output_type -> api.AdjustStockResponse
+	78, // [78:103] is the sub-list for method output_type
+	53, // [53:78] is the sub-list for method input_type
+	53, // [53:53] is the sub-list for extension type_name
+	53, // [53:53] is the sub-list for extension extendee
+	0,  // [0:53] is the sub-list for field type_name
 }
 
 func init() { file_api_order_proto_init() }
@@ -639,13 +4032,14 @@ func file_api_order_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_order_proto_rawDesc), len(file_api_order_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   11,
+			NumEnums:      3,
+			NumMessages:   61,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_api_order_proto_goTypes,
 		DependencyIndexes: file_api_order_proto_depIdxs,
+		EnumInfos:         file_api_order_proto_enumTypes,
 		MessageInfos:      file_api_order_proto_msgTypes,
 	}.Build()
 	File_api_order_proto = out.File